@@ -60,10 +60,17 @@ func runInfo(cmd *cobra.Command, args []string) error {
 			fmt.Println("Kind: store")
 			fmt.Println("Value Type:", v.KindStore.ValueType)
 			fmt.Println("Update Policy:", v.KindStore.UpdatePolicy)
+		case *pbsubstreams.Module_KindIndex_:
+			fmt.Println("Kind: index")
+			fmt.Println("Output Type:", v.KindIndex.OutputType)
 		default:
 			fmt.Println("Kind: Unknown")
 		}
 
+		if filter := module.BlockFilter; filter != nil {
+			fmt.Printf("Block filter: %s (%s)\n", filter.Query, filter.Module)
+		}
+
 		hashes.HashModule(pkg.Modules, module, graph)
 
 		fmt.Println("Hash:", hashes.Get(module.Name))
@@ -21,10 +21,12 @@ func init() {
 	runCmd.Flags().StringP("stop-block", "t", "0", "Stop block to end stream at, inclusively.")
 
 	runCmd.Flags().BoolP("insecure", "k", false, "Skip certificate validation on GRPC connection")
-	runCmd.Flags().BoolP("plaintext", "p", false, "Establish GRPC connection in plaintext")
+	runCmd.Flags().Bool("plaintext", false, "Establish GRPC connection in plaintext")
 
 	runCmd.Flags().StringP("output", "o", "", "Output mode. Defaults to 'ui' when in a TTY is present, and 'json' otherwise")
 	runCmd.Flags().BoolP("initial-snapshots", "i", false, "Load an initial snapshot at start block, before continuing processing.")
+	runCmd.Flags().StringArrayP("params", "p", nil, "Override the params of a module, in the form 'module_name=value'. Can be repeated, ex: -p mod1=val1 -p mod2=val2")
+	runCmd.Flags().String("min-log-level", "", "Only receive the module logs of this level or above, one of: trace, debug, info, warn, error. Defaults to all logs")
 
 	rootCmd.AddCommand(runCmd)
 }
@@ -105,6 +107,18 @@ func runRun(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	params, err := parseParamsFlag(cmd, "params")
+	if err != nil {
+		return fmt.Errorf("params: %w", err)
+	}
+	req.Params = params
+
+	minLogLevel, err := parseLogLevel(mustGetString(cmd, "min-log-level"))
+	if err != nil {
+		return fmt.Errorf("min log level: %w", err)
+	}
+	req.MinLogLevel = minLogLevel
+
 	if err := pbsubstreams.ValidateRequest(req); err != nil {
 		return fmt.Errorf("validate request: %w", err)
 	}
@@ -134,6 +148,7 @@ func runRun(cmd *cobra.Command, args []string) error {
 			if err == io.EOF {
 				ui.Cancel()
 				fmt.Println("all done")
+				ui.PrintTimings()
 				return nil
 			}
 			return err
@@ -151,6 +166,35 @@ func readAPIToken(cmd *cobra.Command, envFlagName string) string {
 	return os.Getenv("SF_API_TOKEN")
 }
 
+func parseLogLevel(in string) (pbsubstreams.LogLevel, error) {
+	if in == "" {
+		return pbsubstreams.LogLevel_LOG_LEVEL_UNSET, nil
+	}
+
+	level, found := pbsubstreams.LogLevel_value["LOG_LEVEL_"+strings.ToUpper(in)]
+	if !found || level == int32(pbsubstreams.LogLevel_LOG_LEVEL_UNSET) {
+		return 0, fmt.Errorf("invalid value %q, expected one of: trace, debug, info, warn, error", in)
+	}
+	return pbsubstreams.LogLevel(level), nil
+}
+
+func parseParamsFlag(cmd *cobra.Command, flagName string) (map[string]string, error) {
+	values := mustGetStringArray(cmd, flagName)
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	out := make(map[string]string, len(values))
+	for _, val := range values {
+		parts := strings.SplitN(val, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid value %q, expected 'module_name=value'", val)
+		}
+		out[parts[0]] = parts[1]
+	}
+	return out, nil
+}
+
 func readStopBlockFlag(cmd *cobra.Command, startBlock int64, flagName string) (uint64, error) {
 	val, err := cmd.Flags().GetString(flagName)
 	if err != nil {
@@ -0,0 +1,199 @@
+// Package compress compresses the files written to the state store, store
+// snapshots and output caches. Readers detect the codec from the magic bytes
+// of the data, so files written with any codec, or none, can be read back
+// whatever the current configuration.
+package compress
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+type Codec string
+
+const (
+	None Codec = "none"
+	Zstd Codec = "zstd"
+	Gzip Codec = "gzip"
+)
+
+var (
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+	gzipMagic = []byte{0x1f, 0x8b}
+)
+
+// Compression is a codec and its level. A zero level uses the default level
+// of the codec, other levels being the ones of the reference implementations:
+// 1 to 22 for zstd, 1 to 9 for gzip.
+type Compression struct {
+	Codec Codec
+	Level int
+}
+
+// Default leaves the files uncompressed, readable by previous versions.
+var Default = Compression{Codec: None}
+
+func New(codec string, level int) (Compression, error) {
+	c := Compression{Codec: Codec(codec), Level: level}
+	switch c.Codec {
+	case None:
+	case Zstd:
+		if level < 0 || level > 22 {
+			return c, fmt.Errorf("invalid zstd level %d, expected 0, the default level, or 1 to 22", level)
+		}
+	case Gzip:
+		if level < 0 || level > gzip.BestCompression {
+			return c, fmt.Errorf("invalid gzip level %d, expected 0, the default level, or 1 to 9", level)
+		}
+	default:
+		return c, fmt.Errorf("unknown compression codec %q, expected one of none, zstd or gzip", codec)
+	}
+	return c, nil
+}
+
+func (c Compression) String() string {
+	if c.Level == 0 || c.Codec == None || c.Codec == "" {
+		return string(c.Codec)
+	}
+	return fmt.Sprintf("%s:%d", c.Codec, c.Level)
+}
+
+// Compress returns `data` compressed, or `data` itself for codec `None`.
+func (c Compression) Compress(data []byte) ([]byte, error) {
+	switch c.Codec {
+	case None, "":
+		return data, nil
+	case Zstd:
+		encoder, err := zstdEncoder(c.Level)
+		if err != nil {
+			return nil, err
+		}
+		return encoder.EncodeAll(data, make([]byte, 0, len(data)/2)), nil
+	}
+
+	buffer := &bytes.Buffer{}
+	w, err := c.NewWriter(buffer)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// NewWriter compresses what is written to it into `w`, until closed. Closing
+// it does not close `w`.
+func (c Compression) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	switch c.Codec {
+	case None, "":
+		return nopWriteCloser{w}, nil
+	case Zstd:
+		return zstd.NewWriter(w, zstd.WithEncoderLevel(zstdLevel(c.Level)), zstd.WithEncoderConcurrency(1))
+	case Gzip:
+		level := c.Level
+		if level == 0 {
+			level = gzip.DefaultCompression
+		}
+		return gzip.NewWriterLevel(w, level)
+	}
+	return nil, fmt.Errorf("unknown compression codec %q", c.Codec)
+}
+
+// Decompress returns `data` decompressed, `data` being returned as is when
+// not compressed.
+func Decompress(data []byte) ([]byte, error) {
+	switch {
+	case bytes.HasPrefix(data, zstdMagic):
+		out, err := zstdDecoder.DecodeAll(data, nil)
+		if err != nil {
+			return nil, fmt.Errorf("zstd: %w", err)
+		}
+		return out, nil
+	case bytes.HasPrefix(data, gzipMagic):
+		r, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("gzip: %w", err)
+		}
+		out, err := io.ReadAll(r)
+		if err != nil {
+			return nil, fmt.Errorf("gzip: %w", err)
+		}
+		return out, nil
+	}
+	return data, nil
+}
+
+// NewReader decompresses what is read from `r`, which is read as is when not
+// compressed. Closing it does not close `r`.
+func NewReader(r io.Reader) (io.ReadCloser, error) {
+	buffered := bufio.NewReader(r)
+	magic, err := buffered.Peek(len(zstdMagic))
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	switch {
+	case bytes.HasPrefix(magic, zstdMagic):
+		decoder, err := zstd.NewReader(buffered, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, fmt.Errorf("zstd: %w", err)
+		}
+		return decoder.IOReadCloser(), nil
+	case bytes.HasPrefix(magic, gzipMagic):
+		decoder, err := gzip.NewReader(buffered)
+		if err != nil {
+			return nil, fmt.Errorf("gzip: %w", err)
+		}
+		return decoder, nil
+	}
+	return io.NopCloser(buffered), nil
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
+// zstdDecoder is safe for concurrent `DecodeAll` calls.
+var zstdDecoder, _ = zstd.NewReader(nil)
+
+// zstdEncoders keeps one encoder per level, an encoder being costly to create
+// and safe for concurrent `EncodeAll` calls.
+var zstdEncoders = struct {
+	sync.Mutex
+	byLevel map[zstd.EncoderLevel]*zstd.Encoder
+}{byLevel: map[zstd.EncoderLevel]*zstd.Encoder{}}
+
+func zstdEncoder(level int) (*zstd.Encoder, error) {
+	encoderLevel := zstdLevel(level)
+
+	zstdEncoders.Lock()
+	defer zstdEncoders.Unlock()
+	if encoder, found := zstdEncoders.byLevel[encoderLevel]; found {
+		return encoder, nil
+	}
+	encoder, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(encoderLevel))
+	if err != nil {
+		return nil, err
+	}
+	zstdEncoders.byLevel[encoderLevel] = encoder
+	return encoder, nil
+}
+
+func zstdLevel(level int) zstd.EncoderLevel {
+	if level == 0 {
+		return zstd.SpeedDefault
+	}
+	return zstd.EncoderLevelFromZstd(level)
+}
@@ -0,0 +1,78 @@
+package compress
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompression_roundTrip(t *testing.T) {
+	data := bytes.Repeat([]byte(`{"key":"value"}`), 1000)
+
+	tests := []struct {
+		compression  Compression
+		expectPrefix []byte
+	}{
+		{Compression{Codec: None}, []byte(`{"key"`)},
+		{Compression{Codec: Zstd}, zstdMagic},
+		{Compression{Codec: Zstd, Level: 19}, zstdMagic},
+		{Compression{Codec: Gzip}, gzipMagic},
+		{Compression{Codec: Gzip, Level: 1}, gzipMagic},
+	}
+
+	for _, test := range tests {
+		t.Run(test.compression.String(), func(t *testing.T) {
+			compressed, err := test.compression.Compress(data)
+			require.NoError(t, err)
+			assert.True(t, bytes.HasPrefix(compressed, test.expectPrefix))
+
+			out, err := Decompress(compressed)
+			require.NoError(t, err)
+			assert.Equal(t, data, out)
+
+			buffer := &bytes.Buffer{}
+			w, err := test.compression.NewWriter(buffer)
+			require.NoError(t, err)
+			_, err = w.Write(data)
+			require.NoError(t, err)
+			require.NoError(t, w.Close())
+
+			r, err := NewReader(buffer)
+			require.NoError(t, err)
+			out, err = io.ReadAll(r)
+			require.NoError(t, err)
+			require.NoError(t, r.Close())
+			assert.Equal(t, data, out)
+		})
+	}
+}
+
+func TestDecompress_corrupted(t *testing.T) {
+	compressed, err := Compression{Codec: Zstd}.Compress([]byte("some data"))
+	require.NoError(t, err)
+
+	_, err = Decompress(compressed[:len(compressed)-2])
+	assert.Error(t, err)
+}
+
+func TestNew(t *testing.T) {
+	c, err := New("gzip", 9)
+	require.NoError(t, err)
+	assert.Equal(t, "gzip:9", c.String())
+
+	_, err = New("zstd", 23)
+	assert.EqualError(t, err, "invalid zstd level 23, expected 0, the default level, or 1 to 22")
+
+	c, err = New("zstd", 0)
+	require.NoError(t, err)
+	assert.Equal(t, "zstd", c.String())
+
+	_, err = New("gzip", -1)
+	assert.EqualError(t, err, "invalid gzip level -1, expected 0, the default level, or 1 to 9")
+
+	_, err = New("lz4", 0)
+	assert.EqualError(t, err, `unknown compression codec "lz4", expected one of none, zstd or gzip`)
+}
@@ -0,0 +1,137 @@
+package index
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/streamingfast/derr"
+	"github.com/streamingfast/dstore"
+	"github.com/streamingfast/substreams/block"
+)
+
+// File holds the keys emitted by an index module for each block of a
+// range. It is saved as `<end>-<start>.index`, with the blocks listed by key.
+type File struct {
+	Range *block.Range
+
+	blockKeys map[uint64]map[string]bool
+}
+
+type fileData struct {
+	Blocks map[string][]uint64 `json:"blocks"`
+}
+
+func NewFile(r *block.Range) *File {
+	return &File{
+		Range:     r,
+		blockKeys: map[uint64]map[string]bool{},
+	}
+}
+
+func Filename(r *block.Range) string {
+	return fmt.Sprintf("%010d-%010d.index", r.ExclusiveEndBlock, r.StartBlock)
+}
+
+// Add records the keys of `blockNum`, replacing those previously added.
+func (f *File) Add(blockNum uint64, keys []string) {
+	if len(keys) == 0 {
+		delete(f.blockKeys, blockNum)
+		return
+	}
+	set := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		set[key] = true
+	}
+	f.blockKeys[blockNum] = set
+}
+
+// Keys returns the keys of `blockNum`, empty when the block emitted none.
+func (f *File) Keys(blockNum uint64) map[string]bool {
+	return f.blockKeys[blockNum]
+}
+
+// Matches tells if a block of the range may match `query`. Blocks without
+// keys are not listed, so a query matching no keys matches the range.
+func (f *File) Matches(query Query) bool {
+	if query.Matches(nil) {
+		return true
+	}
+	for _, keys := range f.blockKeys {
+		if query.Matches(keys) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *File) Save(ctx context.Context, store dstore.Store) error {
+	data := fileData{Blocks: map[string][]uint64{}}
+	for blockNum, keys := range f.blockKeys {
+		for key := range keys {
+			data.Blocks[key] = append(data.Blocks[key], blockNum)
+		}
+	}
+	for _, blocks := range data.Blocks {
+		sort.Slice(blocks, func(i, j int) bool { return blocks[i] < blocks[j] })
+	}
+
+	content, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("marshal index: %w", err)
+	}
+
+	filename := Filename(f.Range)
+	err = derr.RetryContext(ctx, 3, func(ctx context.Context) error {
+		return store.WriteObject(ctx, filename, bytes.NewReader(content))
+	})
+	if err != nil {
+		return fmt.Errorf("write index file %q: %w", filename, err)
+	}
+	return nil
+}
+
+// LoadFile returns the index of range `r`, or nil when it was not saved.
+func LoadFile(ctx context.Context, store dstore.Store, r *block.Range) (*File, error) {
+	filename := Filename(r)
+	exists, err := store.FileExists(ctx, filename)
+	if err != nil {
+		return nil, fmt.Errorf("checking index file %q: %w", filename, err)
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	var content []byte
+	err = derr.RetryContext(ctx, 3, func(ctx context.Context) error {
+		reader, err := store.OpenObject(ctx, filename)
+		if err != nil {
+			return err
+		}
+		defer reader.Close()
+		content, err = io.ReadAll(reader)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("read index file %q: %w", filename, err)
+	}
+
+	var data fileData
+	if err := json.Unmarshal(content, &data); err != nil {
+		return nil, fmt.Errorf("unmarshal index file %q: %w", filename, err)
+	}
+
+	f := NewFile(r)
+	for key, blocks := range data.Blocks {
+		for _, blockNum := range blocks {
+			if f.blockKeys[blockNum] == nil {
+				f.blockKeys[blockNum] = map[string]bool{}
+			}
+			f.blockKeys[blockNum][key] = true
+		}
+	}
+	return f, nil
+}
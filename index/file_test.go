@@ -0,0 +1,69 @@
+package index
+
+import (
+	"context"
+	"testing"
+
+	"github.com/streamingfast/dstore"
+	"github.com/streamingfast/substreams/block"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFile_SaveLoad(t *testing.T) {
+	ctx := context.Background()
+	store, err := dstore.NewStore("file://"+t.TempDir(), "", "", false)
+	require.NoError(t, err)
+
+	r := block.NewRange(100, 200)
+	loaded, err := LoadFile(ctx, store, r)
+	require.NoError(t, err)
+	assert.Nil(t, loaded)
+
+	file := NewFile(r)
+	file.Add(110, []string{"transfer", "usdc"})
+	file.Add(120, []string{"mint"})
+	file.Add(130, []string{"transfer"})
+	file.Add(130, []string{"approval"})
+	file.Add(140, nil)
+	require.NoError(t, file.Save(ctx, store))
+
+	exists, err := store.FileExists(ctx, "0000000200-0000000100.index")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	loaded, err = LoadFile(ctx, store, r)
+	require.NoError(t, err)
+	require.NotNil(t, loaded)
+	assert.Equal(t, r, loaded.Range)
+	assert.Equal(t, map[string]bool{"transfer": true, "usdc": true}, loaded.Keys(110))
+	assert.Equal(t, map[string]bool{"mint": true}, loaded.Keys(120))
+	assert.Equal(t, map[string]bool{"approval": true}, loaded.Keys(130))
+	assert.Empty(t, loaded.Keys(140))
+}
+
+func TestFile_Matches(t *testing.T) {
+	file := NewFile(block.NewRange(100, 200))
+	file.Add(110, []string{"transfer", "usdc"})
+	file.Add(120, []string{"mint"})
+
+	tests := []struct {
+		query  string
+		expect bool
+	}{
+		{"transfer", true},
+		{"transfer && mint", false},
+		{"mint || burn", true},
+		{"burn", false},
+		{"transfer && !usdc", false},
+		{"!burn", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			query, err := ParseQuery(test.query)
+			require.NoError(t, err)
+			assert.Equal(t, test.expect, file.Matches(query))
+		})
+	}
+}
@@ -0,0 +1,245 @@
+package index
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Query is a boolean expression over the keys emitted by an index module for
+// a block, like `transfer && (usdc || usdt) && !'failed tx'`.
+//
+// Keys are matched exactly, `&&` binds tighter than `||` and `!` tighter
+// than both. Keys containing spaces, parentheses, quotes or operator
+// characters are written between single quotes.
+type Query interface {
+	Matches(keys map[string]bool) bool
+	String() string
+}
+
+func ParseQuery(expr string) (Query, error) {
+	p := &queryParser{input: expr}
+	p.next()
+
+	query, err := p.parseOr()
+	if err != nil {
+		return nil, fmt.Errorf("parsing query %q: %w", expr, err)
+	}
+	if p.token.kind != tokenEOF {
+		return nil, fmt.Errorf("parsing query %q: unexpected %s at offset %d", expr, p.token, p.token.pos)
+	}
+	return query, nil
+}
+
+type keyQuery string
+
+func (q keyQuery) Matches(keys map[string]bool) bool { return keys[string(q)] }
+
+func (q keyQuery) String() string {
+	if strings.ContainsAny(string(q), " \t\n()!&|'") || q == "" {
+		return "'" + string(q) + "'"
+	}
+	return string(q)
+}
+
+type notQuery struct{ operand Query }
+
+func (q notQuery) Matches(keys map[string]bool) bool { return !q.operand.Matches(keys) }
+func (q notQuery) String() string                    { return "!" + operandString(q.operand) }
+
+type andQuery []Query
+
+func (q andQuery) Matches(keys map[string]bool) bool {
+	for _, operand := range q {
+		if !operand.Matches(keys) {
+			return false
+		}
+	}
+	return true
+}
+
+func (q andQuery) String() string { return joinQueries(q, " && ") }
+
+type orQuery []Query
+
+func (q orQuery) Matches(keys map[string]bool) bool {
+	for _, operand := range q {
+		if operand.Matches(keys) {
+			return true
+		}
+	}
+	return false
+}
+
+func (q orQuery) String() string { return joinQueries(q, " || ") }
+
+func joinQueries(queries []Query, separator string) string {
+	parts := make([]string, len(queries))
+	for i, query := range queries {
+		parts[i] = operandString(query)
+	}
+	return strings.Join(parts, separator)
+}
+
+// operandString parenthesizes the `&&` and `||` operands of another operator.
+func operandString(query Query) string {
+	switch query.(type) {
+	case andQuery, orQuery:
+		return "(" + query.String() + ")"
+	}
+	return query.String()
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenKey
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenOpen
+	tokenClose
+	tokenInvalid
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of query"
+	case tokenKey:
+		return fmt.Sprintf("key %q", t.value)
+	case tokenInvalid:
+		return t.value
+	default:
+		return fmt.Sprintf("%q", t.value)
+	}
+}
+
+type queryParser struct {
+	input string
+	pos   int
+	token token
+}
+
+func (p *queryParser) next() {
+	for p.pos < len(p.input) && strings.ContainsRune(" \t\n", rune(p.input[p.pos])) {
+		p.pos++
+	}
+	start := p.pos
+	if p.pos == len(p.input) {
+		p.token = token{kind: tokenEOF, pos: start}
+		return
+	}
+
+	switch rest := p.input[p.pos:]; {
+	case strings.HasPrefix(rest, "&&"):
+		p.pos += 2
+		p.token = token{kind: tokenAnd, value: "&&", pos: start}
+	case strings.HasPrefix(rest, "||"):
+		p.pos += 2
+		p.token = token{kind: tokenOr, value: "||", pos: start}
+	case rest[0] == '!':
+		p.pos++
+		p.token = token{kind: tokenNot, value: "!", pos: start}
+	case rest[0] == '(':
+		p.pos++
+		p.token = token{kind: tokenOpen, value: "(", pos: start}
+	case rest[0] == ')':
+		p.pos++
+		p.token = token{kind: tokenClose, value: ")", pos: start}
+	case rest[0] == '\'':
+		end := strings.IndexByte(rest[1:], '\'')
+		if end == -1 {
+			p.pos = len(p.input)
+			p.token = token{kind: tokenInvalid, value: "unterminated quoted key", pos: start}
+			return
+		}
+		p.pos += end + 2
+		p.token = token{kind: tokenKey, value: rest[1 : end+1], pos: start}
+	default:
+		end := strings.IndexAny(rest, " \t\n()!&|'")
+		if end == -1 {
+			end = len(rest)
+		}
+		if end == 0 {
+			p.pos++
+			p.token = token{kind: tokenInvalid, value: fmt.Sprintf("character %q", rest[0]), pos: start}
+			return
+		}
+		p.pos += end
+		p.token = token{kind: tokenKey, value: rest[:end], pos: start}
+	}
+}
+
+func (p *queryParser) parseOr() (Query, error) {
+	var operands orQuery
+	for {
+		operand, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, operand)
+		if p.token.kind != tokenOr {
+			break
+		}
+		p.next()
+	}
+	if len(operands) == 1 {
+		return operands[0], nil
+	}
+	return operands, nil
+}
+
+func (p *queryParser) parseAnd() (Query, error) {
+	var operands andQuery
+	for {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, operand)
+		if p.token.kind != tokenAnd {
+			break
+		}
+		p.next()
+	}
+	if len(operands) == 1 {
+		return operands[0], nil
+	}
+	return operands, nil
+}
+
+func (p *queryParser) parseUnary() (Query, error) {
+	switch p.token.kind {
+	case tokenNot:
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notQuery{operand}, nil
+	case tokenOpen:
+		p.next()
+		query, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.token.kind != tokenClose {
+			return nil, fmt.Errorf("expected \")\" at offset %d, got %s", p.token.pos, p.token)
+		}
+		p.next()
+		return query, nil
+	case tokenKey:
+		key := keyQuery(p.token.value)
+		p.next()
+		return key, nil
+	default:
+		return nil, fmt.Errorf("expected a key at offset %d, got %s", p.token.pos, p.token)
+	}
+}
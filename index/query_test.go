@@ -0,0 +1,79 @@
+package index
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		expr       string
+		expect     string
+		matches    []string
+		notMatches []string
+	}{
+		{"transfer", "transfer", []string{"transfer"}, []string{"mint"}},
+		{"a || b && c", "a || (b && c)", []string{"a", "b c"}, []string{"b", "c"}},
+		{"(a || b) && c", "(a || b) && c", []string{"a c", "b c"}, []string{"a", "b"}},
+		{"!a", "!a", []string{"", "b"}, []string{"a"}},
+		{"!!a", "!!a", []string{"a"}, []string{""}},
+		{"a && !(b || c)", "a && !(b || c)", []string{"a"}, []string{"a b", "a c"}},
+		{"'failed tx' || a", "'failed tx' || a", []string{"a"}, []string{"failed", "tx"}},
+		{"  a&&b  ", "a && b", []string{"a b"}, []string{"a"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expr, func(t *testing.T) {
+			query, err := ParseQuery(test.expr)
+			require.NoError(t, err)
+			assert.Equal(t, test.expect, query.String())
+
+			for _, keys := range test.matches {
+				assert.True(t, query.Matches(keySet(keys)), "should match %q", keys)
+			}
+			for _, keys := range test.notMatches {
+				assert.False(t, query.Matches(keySet(keys)), "should not match %q", keys)
+			}
+		})
+	}
+}
+
+func TestParseQuery_quotedKeys(t *testing.T) {
+	query, err := ParseQuery("'failed tx' && !'a&b'")
+	require.NoError(t, err)
+	assert.True(t, query.Matches(map[string]bool{"failed tx": true}))
+	assert.False(t, query.Matches(map[string]bool{"failed tx": true, "a&b": true}))
+}
+
+func TestParseQuery_errors(t *testing.T) {
+	tests := []struct {
+		expr        string
+		expectError string
+	}{
+		{"", `parsing query "": expected a key at offset 0, got end of query`},
+		{"a &&", `parsing query "a &&": expected a key at offset 4, got end of query`},
+		{"a b", `parsing query "a b": unexpected key "b" at offset 2`},
+		{"(a || b", `parsing query "(a || b": expected ")" at offset 7, got end of query`},
+		{"a)", `parsing query "a)": unexpected ")" at offset 1`},
+		{"a & b", `parsing query "a & b": unexpected character '&' at offset 2`},
+		{"'a", `parsing query "'a": expected a key at offset 0, got unterminated quoted key`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expr, func(t *testing.T) {
+			_, err := ParseQuery(test.expr)
+			require.EqualError(t, err, test.expectError)
+		})
+	}
+}
+
+func keySet(keys string) map[string]bool {
+	out := map[string]bool{}
+	for _, key := range strings.Fields(keys) {
+		out[key] = true
+	}
+	return out
+}
@@ -52,6 +52,13 @@ func NewModuleGraph(modules []*pbsubstreams.Module) (*ModuleGraph, error) {
 				g.AddCost(i, j, 1)
 			}
 		}
+
+		// the index module must run before the module it filters
+		if filter := module.BlockFilter; filter != nil {
+			if j, found := g.moduleIndex[filter.Module]; found {
+				g.AddCost(i, j, 1)
+			}
+		}
 	}
 
 	if !graph.Acyclic(g) {
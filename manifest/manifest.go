@@ -25,8 +25,12 @@ func init() {
 const (
 	ModuleKindStore = "store"
 	ModuleKindMap   = "map"
+	ModuleKindIndex = "index"
 )
 
+// IndexKeysType is the output type of `index` modules
+const IndexKeysType = "proto:sf.substreams.v1.IndexKeys"
+
 // Manifest is a YAML structure used to create a Package and its list
 // of Modules. The notion of a manifest does not live in protobuf definitions.
 type Manifest struct {
@@ -75,6 +79,14 @@ type Module struct {
 	//Code         Code         `yaml:"code"`
 	Inputs []*Input     `yaml:"inputs"`
 	Output StreamOutput `yaml:"output"`
+	Params *string      `yaml:"params"`
+
+	BlockFilter *BlockFilter `yaml:"blockFilter"`
+}
+
+type BlockFilter struct {
+	Module string `yaml:"module"`
+	Query  string `yaml:"query"`
 }
 
 type Input struct {
@@ -152,19 +164,23 @@ func validateStoreBuilder(module *Module) error {
 		"max:bigint",
 		"max:int64",
 		"max:bigfloat",
+		"max:bigdecimal",
 		"max:float64",
 		"min:bigint",
 		"min:int64",
 		"min:bigfloat",
+		"min:bigdecimal",
 		"min:float64",
 		"add:bigint",
 		"add:int64",
 		"add:bigfloat",
+		"add:bigdecimal",
 		"add:float64",
 		"set:bytes",
 		"set:string",
 		"set:proto",
 		"set:bigfloat",
+		"set:bigdecimal",
 		"set:bigint",
 		"set:int64",
 		"set:float64",
@@ -172,6 +188,7 @@ func validateStoreBuilder(module *Module) error {
 		"set_if_not_exists:string",
 		"set_if_not_exists:proto",
 		"set_if_not_exists:bigfloat",
+		"set_if_not_exists:bigdecimal",
 		"set_if_not_exists:bigint",
 		"set_if_not_exists:int64",
 		"set_if_not_exists:float64",
@@ -214,6 +231,17 @@ func (m *Module) ToProtoWASM(codeIndex uint32) (*pbsubstreams.Module, error) {
 		out.InitialBlock = *m.InitialBlock
 	}
 
+	if m.Params != nil {
+		out.Params = &pbsubstreams.Module_Params{Value: *m.Params}
+	}
+
+	if m.BlockFilter != nil {
+		out.BlockFilter = &pbsubstreams.Module_BlockFilter{
+			Module: m.BlockFilter.Module,
+			Query:  m.BlockFilter.Query,
+		}
+	}
+
 	m.setOutputToProto(out)
 	m.setKindToProto(out)
 	err := m.setInputsToProto(out)
@@ -298,6 +326,12 @@ func (m *Module) setKindToProto(pbModule *pbsubstreams.Module) {
 				OutputType: m.Output.Type,
 			},
 		}
+	case ModuleKindIndex:
+		pbModule.Kind = &pbsubstreams.Module_KindIndex_{
+			KindIndex: &pbsubstreams.Module_KindIndex{
+				OutputType: m.Output.Type,
+			},
+		}
 	case ModuleKindStore:
 		var updatePolicy pbsubstreams.Module_KindStore_UpdatePolicy
 		switch m.UpdatePolicy {
@@ -61,6 +61,24 @@ inputs:
 				Inputs:       []*Input{{Source: "proto:sf.ethereum.type.v1.Block"}, {Store: "pairs"}},
 			},
 		},
+		{
+			name: "mapper with params",
+			rawYamlInput: `---
+name: transfers
+kind: map
+inputs:
+  - source: proto:sf.ethereum.type.v1.Block
+params: "0xbc4ca0eda7647a8ab7c2061c2e118a18a936f13d"
+output:
+  type: proto:eth.erc721.v1.Transfers`,
+			expectedOutput: Module{
+				Name:   "transfers",
+				Kind:   "map",
+				Inputs: []*Input{{Source: "proto:sf.ethereum.type.v1.Block"}},
+				Output: StreamOutput{Type: "proto:eth.erc721.v1.Transfers"},
+				Params: strPtr("0xbc4ca0eda7647a8ab7c2061c2e118a18a936f13d"),
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -71,6 +89,10 @@ inputs:
 	}
 }
 
+func strPtr(in string) *string {
+	return &in
+}
+
 //func TestStream_Signature_Basic(t *testing.T) {
 //	manifest, err := newWithoutLoad("./test/test_manifest.yaml")
 //	require.NoError(t, err)
@@ -17,6 +17,12 @@ func PrintMermaid(mods *pbsubstreams.Modules) {
 			fmt.Printf("  %s[map: %s]\n", s.Name, s.Name)
 		case *pbsubstreams.Module_KindStore_:
 			fmt.Printf("  %s[store: %s]\n", s.Name, s.Name)
+		case *pbsubstreams.Module_KindIndex_:
+			fmt.Printf("  %s[index: %s]\n", s.Name, s.Name)
+		}
+
+		if filter := s.BlockFilter; filter != nil {
+			fmt.Printf("  %s -. filter .-> %s\n", filter.Module, s.Name)
 		}
 
 		for _, in := range s.Inputs {
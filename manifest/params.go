@@ -0,0 +1,24 @@
+package manifest
+
+import (
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+)
+
+// ApplyParams overrides the `params` value of each module named in `params`,
+// as received in a `Request`. It must be called before any module hash is
+// computed, since the params are part of the hash. Overrides for modules that
+// do not declare params are expected to have been rejected by
+// `pbsubstreams.ValidateRequest`.
+func ApplyParams(params map[string]string, modules *pbsubstreams.Modules) {
+	if len(params) == 0 {
+		return
+	}
+
+	for _, mod := range modules.Modules {
+		value, found := params[mod.Name]
+		if !found || mod.Params == nil {
+			continue
+		}
+		mod.Params.Value = value
+	}
+}
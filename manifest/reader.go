@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/streamingfast/substreams/index"
 	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
 	"go.uber.org/zap"
 	"golang.org/x/mod/semver"
@@ -194,6 +195,16 @@ func ValidateModules(mods *pbsubstreams.Modules) error {
 			}
 		}
 
+		if kind := mod.GetKindIndex(); kind != nil && kind.OutputType != IndexKeysType {
+			return fmt.Errorf("module %q: index output type must be %q", mod.Name, IndexKeysType)
+		}
+
+		if filter := mod.BlockFilter; filter != nil {
+			if err := validateBlockFilter(mods, mod, filter); err != nil {
+				return fmt.Errorf("module %q: block filter: %w", mod.Name, err)
+			}
+		}
+
 		if len(mod.Inputs) > 30 {
 			return fmt.Errorf("limit of 30 inputs for a given module (%q) reached", mod.Name)
 		}
@@ -245,6 +256,30 @@ func ValidateModules(mods *pbsubstreams.Modules) error {
 	return nil
 }
 
+func validateBlockFilter(mods *pbsubstreams.Modules, mod *pbsubstreams.Module, filter *pbsubstreams.Module_BlockFilter) error {
+	if mod.GetKindIndex() != nil {
+		return fmt.Errorf("index modules cannot be filtered")
+	}
+
+	var indexModule *pbsubstreams.Module
+	for _, mod2 := range mods.Modules {
+		if mod2.Name == filter.Module {
+			indexModule = mod2
+		}
+	}
+	if indexModule == nil {
+		return fmt.Errorf("module %q not found", filter.Module)
+	}
+	if indexModule.GetKindIndex() == nil {
+		return fmt.Errorf("referenced module %q not of 'index' kind", filter.Module)
+	}
+
+	if _, err := index.ParseQuery(filter.Query); err != nil {
+		return err
+	}
+	return nil
+}
+
 func loadManifestFile(inputPath string) (*Manifest, error) {
 	m, err := decodeYamlManifestFromFile(inputPath)
 	if err != nil {
@@ -287,10 +322,23 @@ func loadManifestFile(inputPath string) (*Manifest, error) {
 			if err := validateStoreBuilder(s); err != nil {
 				return nil, fmt.Errorf("stream %q: %w", s.Name, err)
 			}
+		case ModuleKindIndex:
+			if s.Output.Type == "" {
+				s.Output.Type = IndexKeysType
+			}
+			if s.Output.Type != IndexKeysType {
+				return nil, fmt.Errorf("stream %q: 'output.type' must be %q for kind 'index'", s.Name, IndexKeysType)
+			}
+			if s.BlockFilter != nil {
+				return nil, fmt.Errorf("stream %q: kind 'index' cannot have a 'blockFilter'", s.Name)
+			}
 
 		default:
 			return nil, fmt.Errorf("stream %q: invalid kind %q", s.Name, s.Kind)
 		}
+		if s.BlockFilter != nil && (s.BlockFilter.Module == "" || s.BlockFilter.Query == "") {
+			return nil, fmt.Errorf("stream %q: 'blockFilter' requires a 'module' and a 'query'", s.Name)
+		}
 		for idx, input := range s.Inputs {
 			if err := input.parse(); err != nil {
 				return nil, fmt.Errorf("module %q: invalid input [%d]: %w", s.Name, idx, err)
@@ -337,6 +385,9 @@ func prefixModules(mods []*pbsubstreams.Module, prefix string) {
 				panic(fmt.Sprintf("unsupported module type %s", inputIface.Input))
 			}
 		}
+		if mod.BlockFilter != nil {
+			mod.BlockFilter.Module = prefix + PrefixSeparator + mod.BlockFilter.Module
+		}
 	}
 }
 
@@ -435,7 +486,7 @@ func (r *Reader) convertToPkg(m *Manifest) (pkg *pbsubstreams.Package, err error
 		}
 
 		switch binaryDef.Type {
-		case "wasm/rust-v1":
+		case "wasm/rust-v1", "wasm/wasi-v1":
 			// OPTIM(abourget): also check if it's not already in
 			// `Binaries`, by comparing its, length + hash or value.
 			codeIndex, found := moduleCodeIndexes[binaryDef.File]
@@ -453,6 +504,23 @@ func (r *Reader) convertToPkg(m *Manifest) (pkg *pbsubstreams.Package, err error
 				moduleCodeIndexes[binaryDef.File] = codeIndex
 			}
 			pbmod, err = mod.ToProtoWASM(uint32(codeIndex))
+		case "native/go-v1":
+			if binaryDef.Native == "" {
+				return nil, fmt.Errorf("module %q: binary %q of type %q must specify 'native'", mod.Name, binaryName, binaryDef.Type)
+			}
+			if name, version, found := strings.Cut(binaryDef.Native, "@"); !found || name == "" || version == "" {
+				return nil, fmt.Errorf("module %q: binary %q of type %q: 'native' must be <name>@<version>, got %q", mod.Name, binaryName, binaryDef.Type, binaryDef.Native)
+			}
+			// The content of a native binary is the name its handlers are
+			// registered under on the server and their version
+			codeKey := binaryDef.Type + ":" + binaryDef.Native
+			codeIndex, found := moduleCodeIndexes[codeKey]
+			if !found {
+				pkg.Modules.Binaries = append(pkg.Modules.Binaries, &pbsubstreams.Binary{Type: binaryDef.Type, Content: []byte(binaryDef.Native)})
+				codeIndex = len(pkg.Modules.Binaries) - 1
+				moduleCodeIndexes[codeKey] = codeIndex
+			}
+			pbmod, err = mod.ToProtoWASM(uint32(codeIndex))
 		default:
 			return nil, fmt.Errorf("module %q: invalid code type %q", mod.Name, binaryDef.Type)
 		}
@@ -469,10 +537,11 @@ func (r *Reader) convertToPkg(m *Manifest) (pkg *pbsubstreams.Package, err error
 }
 
 var validValueTypes = map[string]bool{
-	"bigint":   true,
-	"int64":    true,
-	"bigfloat": true,
-	"bytes":    true,
-	"string":   true,
-	"proto":    true,
+	"bigint":     true,
+	"int64":      true,
+	"bigfloat":   true,
+	"bigdecimal": true,
+	"bytes":      true,
+	"string":     true,
+	"proto":      true,
 }
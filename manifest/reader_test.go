@@ -206,3 +206,48 @@ func readSystemProtoDescriptors(t *testing.T) (out []*descriptorpb.FileDescripto
 
 	return systemProtoFiles.File
 }
+
+func TestReader_convertToPkg_native(t *testing.T) {
+	m := &Manifest{
+		Binaries: map[string]Binary{
+			"default": {Type: "native/go-v1", Native: "handlers@v1"},
+			"other":   {Type: "native/go-v1"},
+		},
+		Modules: []*Module{
+			{Name: "map_a", Kind: ModuleKindMap, Inputs: []*Input{{Source: "sf.substreams.v1.Clock"}}, Output: StreamOutput{Type: "proto:a"}},
+			{Name: "map_b", Kind: ModuleKindMap, Inputs: []*Input{{Source: "sf.substreams.v1.Clock"}}, Output: StreamOutput{Type: "proto:b"}},
+		},
+	}
+
+	pkg, err := NewReader("").convertToPkg(m)
+	require.NoError(t, err)
+	require.Equal(t, []*pbsubstreams.Binary{{Type: "native/go-v1", Content: []byte("handlers@v1")}}, pkg.Modules.Binaries)
+	require.Equal(t, uint32(0), pkg.Modules.Modules[1].BinaryIndex)
+	require.Equal(t, "map_b", pkg.Modules.Modules[1].BinaryEntrypoint)
+
+	m.Modules[1].Binary = "other"
+	_, err = NewReader("").convertToPkg(m)
+	require.EqualError(t, err, `module "map_b": binary "other" of type "native/go-v1" must specify 'native'`)
+
+	m.Binaries["other"] = Binary{Type: "native/go-v1", Native: "handlers"}
+	_, err = NewReader("").convertToPkg(m)
+	require.EqualError(t, err, `module "map_b": binary "other" of type "native/go-v1": 'native' must be <name>@<version>, got "handlers"`)
+}
+
+func TestValidateModules_blockFilter(t *testing.T) {
+	indexKind := &pbsubstreams.Module_KindIndex_{KindIndex: &pbsubstreams.Module_KindIndex{OutputType: IndexKeysType}}
+	mapKind := &pbsubstreams.Module_KindMap_{KindMap: &pbsubstreams.Module_KindMap{OutputType: "proto:a"}}
+
+	newModules := func(filter *pbsubstreams.Module_BlockFilter) *pbsubstreams.Modules {
+		return &pbsubstreams.Modules{Modules: []*pbsubstreams.Module{
+			{Name: "index_a", Kind: indexKind},
+			{Name: "map_a", Kind: mapKind},
+			{Name: "map_b", Kind: mapKind, BlockFilter: filter},
+		}}
+	}
+
+	require.NoError(t, ValidateModules(newModules(&pbsubstreams.Module_BlockFilter{Module: "index_a", Query: "transfer && !mint"})))
+	require.EqualError(t, ValidateModules(newModules(&pbsubstreams.Module_BlockFilter{Module: "index_b", Query: "transfer"})), `module "map_b": block filter: module "index_b" not found`)
+	require.EqualError(t, ValidateModules(newModules(&pbsubstreams.Module_BlockFilter{Module: "map_a", Query: "transfer"})), `module "map_b": block filter: referenced module "map_a" not of 'index' kind`)
+	require.EqualError(t, ValidateModules(newModules(&pbsubstreams.Module_BlockFilter{Module: "index_a", Query: "transfer &&"})), `module "map_b": block filter: parsing query "transfer &&": expected a key at offset 11, got end of query`)
+}
@@ -54,6 +54,8 @@ func (m *ModuleHashes) HashModule(modules *pbsubstreams.Modules, module *pbsubst
 		buf.WriteString("map")
 	case *pbsubstreams.Module_KindStore_:
 		buf.WriteString("store")
+	case *pbsubstreams.Module_KindIndex_:
+		buf.WriteString("index")
 	default:
 		panic(fmt.Sprintf("invalid module file %T", module.Kind))
 	}
@@ -68,6 +70,17 @@ func (m *ModuleHashes) HashModule(modules *pbsubstreams.Modules, module *pbsubst
 		buf.WriteString(inputValue(input))
 	}
 
+	if module.Params != nil {
+		buf.WriteString("params")
+		buf.WriteString(module.Params.Value)
+	}
+
+	if module.BlockFilter != nil {
+		buf.WriteString("block_filter")
+		buf.WriteString(module.BlockFilter.Module)
+		buf.WriteString(module.BlockFilter.Query)
+	}
+
 	buf.WriteString("ancestors")
 	ancestors, _ := graph.AncestorsOf(module.Name)
 	for _, ancestor := range ancestors {
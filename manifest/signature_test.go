@@ -62,3 +62,94 @@ func Test_HashModule(t *testing.T) {
 
 	require.NotEqual(t, hashMapPoolsInitialized, hashMapPoolsCreated)
 }
+
+func Test_HashModuleParams(t *testing.T) {
+	newModules := func(params *pbsubstreams.Module_Params) *pbsubstreams.Modules {
+		return &pbsubstreams.Modules{
+			Modules: []*pbsubstreams.Module{
+				{
+					Name:         "map_transfers",
+					InitialBlock: 12369621,
+					Kind: &pbsubstreams.Module_KindMap_{
+						KindMap: &pbsubstreams.Module_KindMap{
+							OutputType: "proto:eth.erc721.v1.Transfers",
+						},
+					},
+					Inputs: []*pbsubstreams.Module_Input{
+						{
+							Input: &pbsubstreams.Module_Input_Source_{
+								Source: &pbsubstreams.Module_Input_Source{
+									Type: "sf.ethereum.type.v1.Block",
+								},
+							},
+						},
+					},
+					Params: params,
+				},
+			},
+			Binaries: []*pbsubstreams.Binary{
+				{
+					Type:    "wasm/rust-v1",
+					Content: []byte("01"),
+				},
+			},
+		}
+	}
+
+	hash := func(modules *pbsubstreams.Modules) ModuleHash {
+		graph, err := NewModuleGraph(modules.Modules)
+		require.NoError(t, err)
+		return NewModuleHashes().HashModule(modules, modules.Modules[0], graph)
+	}
+
+	noParams := hash(newModules(nil))
+	paramsA := hash(newModules(&pbsubstreams.Module_Params{Value: "0xaaaa"}))
+	paramsB := hash(newModules(&pbsubstreams.Module_Params{Value: "0xbbbb"}))
+
+	require.NotEqual(t, noParams, paramsA)
+	require.NotEqual(t, paramsA, paramsB)
+
+	overridden := newModules(&pbsubstreams.Module_Params{Value: "0xaaaa"})
+	ApplyParams(map[string]string{"map_transfers": "0xbbbb"}, overridden)
+	require.Equal(t, paramsB, hash(overridden))
+}
+
+func Test_HashModuleNativeVersion(t *testing.T) {
+	hash := func(content string) ModuleHash {
+		modules := &pbsubstreams.Modules{
+			Modules: []*pbsubstreams.Module{
+				{
+					Name:             "map_transfers",
+					BinaryEntrypoint: "map_transfers",
+					Kind: &pbsubstreams.Module_KindMap_{
+						KindMap: &pbsubstreams.Module_KindMap{
+							OutputType: "proto:eth.erc721.v1.Transfers",
+						},
+					},
+					Inputs: []*pbsubstreams.Module_Input{
+						{
+							Input: &pbsubstreams.Module_Input_Source_{
+								Source: &pbsubstreams.Module_Input_Source{
+									Type: "sf.ethereum.type.v1.Block",
+								},
+							},
+						},
+					},
+				},
+			},
+			Binaries: []*pbsubstreams.Binary{
+				{
+					Type:    "native/go-v1",
+					Content: []byte(content),
+				},
+			},
+		}
+		graph, err := NewModuleGraph(modules.Modules)
+		require.NoError(t, err)
+		return NewModuleHashes().HashModule(modules, modules.Modules[0], graph)
+	}
+
+	// the handlers' version stands for their code
+	require.Equal(t, hash("handlers@v1"), hash("handlers@v1"))
+	require.NotEqual(t, hash("handlers@v1"), hash("handlers@v2"))
+}
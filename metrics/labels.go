@@ -0,0 +1,37 @@
+package metrics
+
+import "sync"
+
+// MaxModuleLabels bounds the distinct module names used as label of the
+// per-module metrics. Module names come from the requests, the modules seen
+// past that bound are all reported under `OtherModulesLabel`.
+const MaxModuleLabels = 200
+
+const OtherModulesLabel = "other"
+
+var moduleLabels = &labelSet{max: MaxModuleLabels, values: map[string]bool{}}
+
+// ModuleLabel returns the label value reporting the module `name`.
+func ModuleLabel(name string) string {
+	return moduleLabels.get(name)
+}
+
+type labelSet struct {
+	sync.Mutex
+	max    int
+	values map[string]bool
+}
+
+func (s *labelSet) get(value string) string {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.values[value] {
+		return value
+	}
+	if len(s.values) >= s.max {
+		return OtherModulesLabel
+	}
+	s.values[value] = true
+	return value
+}
@@ -0,0 +1,16 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabelSet(t *testing.T) {
+	s := &labelSet{max: 2, values: map[string]bool{}}
+	assert.Equal(t, "a", s.get("a"))
+	assert.Equal(t, "b", s.get("b"))
+	assert.Equal(t, OtherModulesLabel, s.get("c"))
+	assert.Equal(t, "a", s.get("a"))
+	assert.Equal(t, OtherModulesLabel, s.get("c"))
+}
@@ -15,3 +15,9 @@ var LastSquashDuration = Metricset.NewGauge("substreams_last_squash_process_dura
 var LastSquashAvgDuration = Metricset.NewGauge("substreams_last_squash_process_avg_duration", "Gauge for monitoring the average individual duration of the most recent complete squash")
 
 var SquashesLaunched = Metricset.NewCounter("substreams_total_squashes_launched", "Counter for Total squash processes launched, used for rate")
+
+var WASMMemorySize = Metricset.NewGaugeVec("substreams_wasm_memory_size_bytes", []string{"module"}, "Gauge for the linear memory size of the most recent execution of a WASM module")
+var WASMMemoryPeakSize = Metricset.NewGaugeVec("substreams_wasm_memory_peak_size_bytes", []string{"module"}, "Gauge for the largest linear memory size reached by a WASM module since the process started")
+
+var ModuleExecutionDuration = Metricset.NewHistogramVec("substreams_module_execution_duration_seconds", []string{"module"}, "Histogram of the wall time of a module execution for a block")
+var ModuleCacheHitDuration = Metricset.NewHistogramVec("substreams_module_cache_hit_duration_seconds", []string{"module"}, "Histogram of the wall time of applying a module's cached output for a block")
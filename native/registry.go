@@ -0,0 +1,153 @@
+// Package native runs Substreams modules implemented in Go and compiled in
+// the server, instead of WASM binaries sent with the request.
+//
+// Handlers are registered in a Registry under a binary name, its version and
+// an entrypoint. A manifest refers to them through a binary of type
+// `native/go-v1` whose `native` field is `<binary name>@<version>`, the
+// module name being the entrypoint, as for WASM modules. The version is part
+// of the module hash in place of the WASM code, so it must change whenever
+// the handlers' outputs do.
+package native
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/streamingfast/substreams/store"
+)
+
+const BinaryTypeGoV1 = "native/go-v1"
+
+// Input is one of the module's inputs, in the order they are declared in the
+// manifest. `Value` is set for source, map and params inputs (nil when
+// empty for this block), `Store` is set for store inputs.
+type Input struct {
+	Name  string
+	Value []byte
+	Store store.Reader
+}
+
+// MapHandler returns the output of a map module for the block of `clock`,
+// the protobuf encoded value of the module's output type.
+type MapHandler func(ctx context.Context, clock *pbsubstreams.Clock, inputs []*Input) ([]byte, error)
+
+// StoreHandler writes to `output` for the block of `clock`, using only the
+// operations allowed by the store's update policy and value type.
+type StoreHandler func(ctx context.Context, clock *pbsubstreams.Clock, inputs []*Input, output store.Store) error
+
+type Registry struct {
+	binaries map[string]*binary
+}
+
+type binary struct {
+	version string
+	maps    map[string]MapHandler
+	stores  map[string]StoreHandler
+}
+
+// BinaryContent is the content of a `native/go-v1` binary referring to the
+// handlers registered under `name` at `version`.
+func BinaryContent(name, version string) string {
+	return name + "@" + version
+}
+
+// ParseBinaryContent splits the content of a `native/go-v1` binary into the
+// binary name and version.
+func ParseBinaryContent(content string) (name, version string, err error) {
+	name, version, found := strings.Cut(content, "@")
+	if !found || name == "" || version == "" {
+		return "", "", fmt.Errorf("native binary %q: expected <name>@<version>", content)
+	}
+	return name, version, nil
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		binaries: map[string]*binary{},
+	}
+}
+
+// RegisterMap registers `handler` under the entrypoint of `binaryName` at
+// `version`, a version or digest of the handlers' code. All the handlers of
+// a binary share its version.
+func (r *Registry) RegisterMap(binaryName, version, entrypoint string, handler MapHandler) {
+	b := r.binary(binaryName, version, entrypoint)
+	b.maps[entrypoint] = handler
+}
+
+// RegisterStore is like RegisterMap, for store handlers.
+func (r *Registry) RegisterStore(binaryName, version, entrypoint string, handler StoreHandler) {
+	b := r.binary(binaryName, version, entrypoint)
+	b.stores[entrypoint] = handler
+}
+
+func (r *Registry) binary(name, version, entrypoint string) *binary {
+	if name == "" || version == "" || strings.Contains(name, "@") {
+		panic(fmt.Sprintf("native binary %q version %q: a name without '@' and a version are required", name, version))
+	}
+	b, found := r.binaries[name]
+	if !found {
+		b = &binary{
+			version: version,
+			maps:    map[string]MapHandler{},
+			stores:  map[string]StoreHandler{},
+		}
+		r.binaries[name] = b
+	}
+	if b.version != version {
+		panic(fmt.Sprintf("native binary %q registered with versions %q and %q", name, b.version, version))
+	}
+	if b.maps[entrypoint] != nil || b.stores[entrypoint] != nil {
+		panic(fmt.Sprintf("native binary %q entrypoint %q already registered", name, entrypoint))
+	}
+	return b
+}
+
+// HasBinary is nil-safe, a nil registry has no binaries.
+func (r *Registry) HasBinary(name string) bool {
+	if r == nil {
+		return false
+	}
+	_, found := r.binaries[name]
+	return found
+}
+
+// CheckBinary returns an error unless the binary name and version of
+// `content`, a `native/go-v1` binary content, are registered.
+func (r *Registry) CheckBinary(content string) error {
+	name, version, err := ParseBinaryContent(content)
+	if err != nil {
+		return err
+	}
+	if !r.HasBinary(name) {
+		return fmt.Errorf("native binary %q is not registered on this server", name)
+	}
+	if registered := r.binaries[name].version; registered != version {
+		return fmt.Errorf("native binary %q is registered at version %q on this server, not %q", name, registered, version)
+	}
+	return nil
+}
+
+func (r *Registry) Map(binaryName, entrypoint string) (MapHandler, error) {
+	if !r.HasBinary(binaryName) {
+		return nil, fmt.Errorf("native binary %q not registered", binaryName)
+	}
+	handler, found := r.binaries[binaryName].maps[entrypoint]
+	if !found {
+		return nil, fmt.Errorf("native binary %q has no map handler %q", binaryName, entrypoint)
+	}
+	return handler, nil
+}
+
+func (r *Registry) Store(binaryName, entrypoint string) (StoreHandler, error) {
+	if !r.HasBinary(binaryName) {
+		return nil, fmt.Errorf("native binary %q not registered", binaryName)
+	}
+	handler, found := r.binaries[binaryName].stores[entrypoint]
+	if !found {
+		return nil, fmt.Errorf("native binary %q has no store handler %q", binaryName, entrypoint)
+	}
+	return handler, nil
+}
@@ -7,12 +7,15 @@ import (
 	"github.com/streamingfast/substreams/block"
 	"github.com/streamingfast/substreams/client"
 	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/streamingfast/substreams/store"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	ttrace "go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+	grpccodes "google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"io"
 	"time"
 )
@@ -150,6 +153,10 @@ func (w *RemoteWorker) Run(ctx context.Context, job *Job, requestModules *pbsubs
 				return partialsWritten, nil
 			}
 			span.SetStatus(codes.Error, err.Error())
+			if status.Code(err) == grpccodes.DataLoss {
+				// the sub-request deleted a corrupted snapshot, retrying would not produce it
+				return nil, fmt.Errorf("receiving stream resp: %w: %s", store.ErrCorruptedSnapshot, status.Convert(err).Message())
+			}
 			return nil, &RetryableErr{cause: fmt.Errorf("receiving stream resp: %w", err)}
 		}
 	}
@@ -39,10 +39,17 @@ func (s *Scheduler) Launch(ctx context.Context, requestModules *pbsubstreams.Mod
 	defer span.End()
 	for {
 		zlog.Debug("getting a next job from scheduler", zap.Int("available_jobs", len(s.availableJobs)))
-		job, ok := <-s.availableJobs
-		if !ok {
-			zlog.Debug("no more job in scheduler, or context cancelled")
-			break
+		var job *Job
+		select {
+		case <-ctx.Done():
+			zlog.Info("synchronize stores quit on cancel context")
+			return
+		case j, ok := <-s.availableJobs:
+			if !ok {
+				zlog.Debug("no more job in scheduler")
+				return
+			}
+			job = j
 		}
 
 		zlog.Info("scheduling job", zap.Object("job", job))
@@ -5,12 +5,14 @@ import (
 	"fmt"
 	"github.com/streamingfast/substreams/block"
 	"github.com/streamingfast/substreams/store"
+	"math"
 	"sort"
 )
 
 type Snapshots struct {
 	Completes block.Ranges // Shortest completes first, largest last.
 	Partials  block.Ranges // First partials first, last last
+	Deltas    block.Ranges // Delta segments, from the end of the snapshot they apply on. First ends first.
 }
 
 func (s *Snapshots) Sort() {
@@ -20,26 +22,72 @@ func (s *Snapshots) Sort() {
 	sort.Slice(s.Partials, func(i, j int) bool {
 		return s.Partials[i].StartBlock < s.Partials[j].StartBlock
 	})
+	sort.Slice(s.Deltas, func(i, j int) bool {
+		return s.Deltas[i].ExclusiveEndBlock < s.Deltas[j].ExclusiveEndBlock
+	})
 }
 
 func (s *Snapshots) String() string {
-	return fmt.Sprintf("completes=%s, partials=%s", s.Completes, s.Partials)
+	return fmt.Sprintf("completes=%s, partials=%s, deltas=%s", s.Completes, s.Partials, s.Deltas)
 }
 
 func (s *Snapshots) LastCompletedBlock() uint64 {
-	if len(s.Completes) == 0 {
+	last := s.LastCompleteSnapshotBefore(math.MaxUint64)
+	if last == nil {
 		return 0
 	}
-	return s.Completes[len(s.Completes)-1].ExclusiveEndBlock
+	return last.ExclusiveEndBlock
 }
 
+// LastCompleteSnapshotBefore returns the range of the last complete store
+// ending at or before `blockNum`, a full snapshot, or a delta segment leading
+// back to one through other delta segments.
 func (s *Snapshots) LastCompleteSnapshotBefore(blockNum uint64) *block.Range {
+	var last *block.Range
 	for i := len(s.Completes); i > 0; i-- {
 		comp := s.Completes[i-1]
 		if comp.ExclusiveEndBlock > blockNum {
 			continue
 		}
-		return comp
+		last = comp
+		break
+	}
+
+	for i := len(s.Deltas); i > 0; i-- {
+		delta := s.Deltas[i-1]
+		if delta.ExclusiveEndBlock > blockNum {
+			continue
+		}
+		if last != nil && delta.ExclusiveEndBlock <= last.ExclusiveEndBlock {
+			break
+		}
+		if checkpoint := s.deltaCheckpoint(delta); checkpoint != nil {
+			return block.NewRange(checkpoint.StartBlock, delta.ExclusiveEndBlock)
+		}
+	}
+	return last
+}
+
+// deltaCheckpoint returns the full snapshot on which `delta` applies, through
+// other delta segments, nil when the chain is broken.
+func (s *Snapshots) deltaCheckpoint(delta *block.Range) *block.Range {
+	for end := delta.StartBlock; ; {
+		if checkpoint := endingAt(s.Completes, end); checkpoint != nil {
+			return checkpoint
+		}
+		previous := endingAt(s.Deltas, end)
+		if previous == nil {
+			return nil
+		}
+		end = previous.StartBlock
+	}
+}
+
+func endingAt(ranges block.Ranges, end uint64) *block.Range {
+	for _, r := range ranges {
+		if r.ExclusiveEndBlock == end {
+			return r
+		}
 	}
 	return nil
 }
@@ -69,6 +117,8 @@ func listSnapshots(ctx context.Context, store store.Store) (*Snapshots, error) {
 	for _, file := range files {
 		if file.Partial {
 			out.Partials = append(out.Partials, block.NewRange(file.StartBlock, file.EndBlock))
+		} else if file.Delta {
+			out.Deltas = append(out.Deltas, block.NewRange(file.StartBlock, file.EndBlock))
 		} else {
 			out.Completes = append(out.Completes, block.NewRange(file.StartBlock, file.EndBlock))
 		}
@@ -42,3 +42,18 @@ func TestSnapshots_LastCompleteBefore(t *testing.T) {
 	assert.Nil(t, s.LastCompleteSnapshotBefore(0))
 	assert.Nil(t, s.LastCompleteSnapshotBefore(5))
 }
+
+func TestSnapshots_LastCompleteBeforeWithDeltas(t *testing.T) {
+	s := &Snapshots{
+		Completes: parseRanges("10-20,10-50"),
+		Deltas:    parseRanges("20-30,30-40,50-60,70-80"), // 70-80 applies on a missing snapshot
+	}
+
+	assert.Nil(t, s.LastCompleteSnapshotBefore(19))
+	assert.Equal(t, parseRange("10-20"), s.LastCompleteSnapshotBefore(29))
+	assert.Equal(t, parseRange("10-30"), s.LastCompleteSnapshotBefore(30))
+	assert.Equal(t, parseRange("10-40"), s.LastCompleteSnapshotBefore(49))
+	assert.Equal(t, parseRange("10-50"), s.LastCompleteSnapshotBefore(50))
+	assert.Equal(t, parseRange("10-60"), s.LastCompleteSnapshotBefore(1000))
+	assert.Equal(t, 60, int(s.LastCompletedBlock()))
+}
@@ -3,6 +3,7 @@ package orchestrator
 import "C"
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -16,6 +17,7 @@ type Squasher struct {
 	storeSquashers       map[string]*StoreSquasher
 	storeSaveInterval    uint64
 	targetExclusiveBlock uint64
+	failed               chan error
 }
 
 // NewSquasher receives stores, initializes them and fetches them from
@@ -34,6 +36,7 @@ func NewSquasher(
 	storeSaveInterval uint64,
 	jobsPlanner *JobsPlanner) (*Squasher, error) {
 	storeSquashers := map[string]*StoreSquasher{}
+	failed := make(chan error, 1)
 	zlog.Info("creating a new squasher", zap.Int("work_plan_count", len(workPlan)))
 
 	for storeModuleName, workUnit := range workPlan {
@@ -61,7 +64,7 @@ func NewSquasher(
 				zap.Object("initial_store_file", workUnit.initialCompleteRange),
 			)
 			if err := clonedStore.Load(ctx, workUnit.initialCompleteRange.ExclusiveEndBlock); err != nil {
-				return nil, fmt.Errorf("load store %q: range %s: %w", storeModuleName, workUnit.initialCompleteRange, err)
+				return nil, fmt.Errorf("load store %q: range %s: %w", storeModuleName, workUnit.initialCompleteRange, deleteCorruptedSnapshot(ctx, clonedStore.BaseStore, err))
 			}
 			storeSquasher = NewStoreSquasher(clonedStore, reqStartBlock, workUnit.initialCompleteRange.ExclusiveEndBlock, storeSaveInterval, jobsPlanner)
 
@@ -71,6 +74,7 @@ func NewSquasher(
 		if len(workUnit.partialsMissing) == 0 {
 			storeSquasher.targetExclusiveEndBlockReach = true
 		}
+		storeSquasher.failed = failed
 
 		go storeSquasher.launch(ctx)
 		storeSquashers[storeModuleName] = storeSquasher
@@ -79,10 +83,30 @@ func NewSquasher(
 	squasher := &Squasher{
 		storeSquashers:       storeSquashers,
 		targetExclusiveBlock: reqStartBlock,
+		failed:               failed,
 	}
 	return squasher, nil
 }
 
+// Failed receives the first error of the store squashers. The jobs depending
+// on a store failing to squash are never scheduled, so the back-processing
+// stops on it instead of waiting for them.
+func (s *Squasher) Failed() <-chan error {
+	return s.failed
+}
+
+// deleteCorruptedSnapshot deletes the snapshot that `err` failed to load when
+// it is corrupted, for the work planned again to produce it, returning `err`.
+func deleteCorruptedSnapshot(ctx context.Context, s *store.BaseStore, err error) error {
+	if !errors.Is(err, store.ErrCorruptedSnapshot) {
+		return err
+	}
+	if deleteErr := s.DeleteCorruptedSnapshot(ctx, err); deleteErr != nil {
+		return fmt.Errorf("%s: %w", err, deleteErr)
+	}
+	return err
+}
+
 func (s *Squasher) WaitTillComplete() error {
 	zlog.Info("squasher waiting till squasher stores are completed",
 		zap.Int("store_count", len(s.storeSquashers)),
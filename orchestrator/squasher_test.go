@@ -0,0 +1,84 @@
+package orchestrator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/streamingfast/dstore"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/streamingfast/substreams/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testStatesPath = "test.module.hash/states/"
+
+func newTestSquasherStores(t *testing.T) (dstore.Store, *store.Map) {
+	t.Helper()
+	fileStore, err := dstore.NewStore("file://"+t.TempDir(), "", "", false)
+	require.NoError(t, err)
+
+	storeMap := store.NewMap()
+	storeMap.Set("test", store.NewTestKVStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", fileStore))
+	return fileStore, storeMap
+}
+
+// truncateSnapshot cuts the end of a snapshot, like a truncated upload.
+func truncateSnapshot(t *testing.T, fileStore dstore.Store, filename string) {
+	t.Helper()
+	ctx := context.Background()
+	r, err := fileStore.OpenObject(ctx, testStatesPath+filename)
+	require.NoError(t, err)
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	require.NoError(t, fileStore.WriteObject(ctx, testStatesPath+filename, bytes.NewReader(data[:len(data)-3])))
+}
+
+func TestNewSquasher_corruptedSnapshot(t *testing.T) {
+	ctx := context.Background()
+	fileStore, storeMap := newTestSquasherStores(t)
+
+	kv := store.NewTestKVStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", fileStore)
+	kv.Set(0, "a", "1")
+	_, err := kv.Save(ctx, 10)
+	require.NoError(t, err)
+	truncateSnapshot(t, fileStore, "0000000010-0000000000.kv")
+
+	workPlan := WorkPlan{"test": SplitWork("test", 10, 0, 20, parseSnapshotSpec("0-10"))}
+	_, err = NewSquasher(ctx, workPlan, storeMap, 20, 10, nil)
+	assert.True(t, errors.Is(err, store.ErrCorruptedSnapshot), err)
+
+	exists, err := fileStore.FileExists(ctx, testStatesPath+"0000000010-0000000000.kv")
+	require.NoError(t, err)
+	assert.False(t, exists, "corrupted snapshot deleted")
+}
+
+func TestSquasher_corruptedPartial(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fileStore, storeMap := newTestSquasherStores(t)
+
+	partial := store.NewTestKVPartialStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", fileStore, 0)
+	partial.Set(0, "a", "1")
+	_, err := partial.Save(ctx, 10)
+	require.NoError(t, err)
+	truncateSnapshot(t, fileStore, "0000000010-0000000000.partial")
+
+	workPlan := WorkPlan{"test": SplitWork("test", 10, 0, 20, parseSnapshotSpec("p0-10"))}
+	squasher, err := NewSquasher(ctx, workPlan, storeMap, 20, 10, nil)
+	require.NoError(t, err)
+	require.NoError(t, workPlan.SquashPartialsPresent(squasher))
+
+	err = <-squasher.Failed()
+	assert.True(t, errors.Is(err, store.ErrCorruptedSnapshot), err)
+	err = squasher.WaitTillComplete()
+	assert.True(t, errors.Is(err, store.ErrCorruptedSnapshot), err)
+
+	exists, err := fileStore.FileExists(ctx, testStatesPath+"0000000010-0000000000.partial")
+	require.NoError(t, err)
+	assert.False(t, exists, "corrupted partial deleted")
+}
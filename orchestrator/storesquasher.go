@@ -27,6 +27,7 @@ type StoreSquasher struct {
 	targetExclusiveEndBlockReach bool
 	partialsChunks               chan block.Ranges
 	waitForCompletion            chan error
+	failed                       chan error
 	storeSaveInterval            uint64
 }
 
@@ -45,7 +46,7 @@ func NewStoreSquasher(
 		jobsPlanner:             jobsPlanner,
 		storeSaveInterval:       storeSaveInterval,
 		partialsChunks:          make(chan block.Ranges, 100 /* before buffering the upstream requests? */),
-		waitForCompletion:       make(chan error),
+		waitForCompletion:       make(chan error, 1),
 		log:                     zlog.With(zap.Object("initial_store", initialStore)),
 	}
 	return s
@@ -102,13 +103,14 @@ func (s *StoreSquasher) launch(ctx context.Context) {
 
 		out, err := s.processRanges(ctx, eg)
 		if err != nil {
-			s.waitForCompletion <- err
+			s.fail(err)
+			return
 		}
 
 		s.log.Info("waiting for eg to finish")
 		if err := eg.Wait(); err != nil {
 			// eg.Wait() will block until everything is done, and return the first error.
-			s.waitForCompletion <- fmt.Errorf("waiting: %w", err)
+			s.fail(fmt.Errorf("waiting: %w", err))
 			return
 		}
 
@@ -128,6 +130,16 @@ func (s *StoreSquasher) launch(ctx context.Context) {
 	}
 }
 
+// fail ends the squashing on `err`, reported to `WaitForCompletion` and, right
+// away, to the `Squasher`.
+func (s *StoreSquasher) fail(err error) {
+	s.waitForCompletion <- err
+	select {
+	case s.failed <- err:
+	default:
+	}
+}
+
 type rangeProgress struct {
 	squashCount           uint64
 	lastExclusiveEndBlock uint64
@@ -190,7 +202,7 @@ func (s *StoreSquasher) processRange(ctx context.Context, eg *llerrgroup.Group,
 
 	nextStore := store.NewPartialKV(s.store.Clone().BaseStore, squashableRange.StartBlock)
 	if err := nextStore.Load(ctx, squashableRange.ExclusiveEndBlock); err != nil {
-		return fmt.Errorf("initializing next partial store %q: %w", s.name, err)
+		return fmt.Errorf("initializing next partial store %q: %w", s.name, deleteCorruptedSnapshot(ctx, nextStore.BaseStore, err))
 	}
 
 	s.log.Debug("merging next store loaded", zap.Object("store", nextStore))
@@ -0,0 +1,103 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/streamingfast/substreams/block"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// testStream replays `responses`, then returns `err`.
+type testStream struct {
+	grpc.ClientStream
+	responses []*pbsubstreams.Response
+	err       error
+}
+
+func (s *testStream) Recv() (*pbsubstreams.Response, error) {
+	if len(s.responses) == 0 {
+		return nil, s.err
+	}
+	resp := s.responses[0]
+	s.responses = s.responses[1:]
+	return resp, nil
+}
+
+func (s *testStream) Header() (metadata.MD, error) { return metadata.MD{}, nil }
+func (s *testStream) Trailer() metadata.MD         { return metadata.MD{} }
+func (s *testStream) CloseSend() error             { return nil }
+
+// testStreamClient serves the streams of `attempts`, one per sub-request.
+type testStreamClient struct {
+	attempts []*testStream
+	calls    int
+}
+
+func (c *testStreamClient) Blocks(ctx context.Context, in *pbsubstreams.Request, opts ...grpc.CallOption) (pbsubstreams.Stream_BlocksClient, error) {
+	stream := c.attempts[c.calls]
+	c.calls++
+	return stream, nil
+}
+
+func progressResponse(progress *pbsubstreams.ModuleProgress) *pbsubstreams.Response {
+	return &pbsubstreams.Response{Message: &pbsubstreams.Response_Progress{Progress: &pbsubstreams.ModulesProgress{Modules: []*pbsubstreams.ModuleProgress{progress}}}}
+}
+
+func TestScheduler_runSingleJob_retries(t *testing.T) {
+	logs := progressResponse(&pbsubstreams.ModuleProgress{Name: "store", Type: &pbsubstreams.ModuleProgress_ProcessedRanges{ProcessedRanges: &pbsubstreams.ModuleProgress_ProcessedRange{}}})
+	failed := progressResponse(&pbsubstreams.ModuleProgress{Name: "store", Type: &pbsubstreams.ModuleProgress_Failed_{Failed: &pbsubstreams.ModuleProgress_Failed{Reason: "out of fuel"}}})
+
+	tests := []struct {
+		name          string
+		attempts      []*testStream
+		expectedCalls int
+		expectedErr   string
+	}{
+		{
+			name: "transient error retried",
+			attempts: []*testStream{
+				{responses: []*pbsubstreams.Response{logs}, err: fmt.Errorf("connection reset")},
+				{err: io.EOF},
+			},
+			expectedCalls: 2,
+		},
+		{
+			name: "failed module not retried",
+			attempts: []*testStream{
+				{responses: []*pbsubstreams.Response{failed}, err: io.EOF},
+				{err: io.EOF},
+			},
+			expectedCalls: 1,
+			expectedErr:   "module store failed on host: out of fuel",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := &testStreamClient{attempts: test.attempts}
+			worker := NewRemoteWorker(func() (pbsubstreams.StreamClient, func() error, []grpc.CallOption, error) {
+				return client, func() error { return nil }, nil, nil
+			})
+			scheduler := &Scheduler{
+				workerPool: NewWorkerPool(1, func() Worker { return worker }),
+				respFunc:   func(resp *pbsubstreams.Response) error { return nil },
+			}
+
+			job := NewJob("store", block.NewRange(0, 100), nil, 1, 0)
+			err := scheduler.runSingleJob(context.Background(), scheduler.workerPool.Borrow(), job, &pbsubstreams.Modules{})
+			if test.expectedErr != "" {
+				assert.EqualError(t, err, test.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, test.expectedCalls, client.calls)
+		})
+	}
+}
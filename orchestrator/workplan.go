@@ -2,6 +2,7 @@ package orchestrator
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/streamingfast/substreams/block"
@@ -73,6 +74,26 @@ type WorkUnit struct {
 	partialsPresent      block.Ranges
 }
 
+// SkipPartials moves to the present partials the missing ones for which
+// `skip` returns true, `skip` being responsible for producing them.
+func (w *WorkUnit) SkipPartials(skip func(r *block.Range) (bool, error)) error {
+	var missing block.Ranges
+	for _, r := range w.partialsMissing {
+		skipped, err := skip(r)
+		if err != nil {
+			return err
+		}
+		if skipped {
+			w.partialsPresent = append(w.partialsPresent, r)
+		} else {
+			missing = append(missing, r)
+		}
+	}
+	w.partialsMissing = missing
+	sort.Sort(w.partialsPresent)
+	return nil
+}
+
 func (w *WorkUnit) initialProcessedPartials() block.Ranges {
 	return w.partialsPresent.Merged()
 }
@@ -133,3 +133,17 @@ func TestSplitSomeWork(t *testing.T) {
 		})
 	}
 }
+
+func TestWorkUnit_SkipPartials(t *testing.T) {
+	unit := &WorkUnit{
+		partialsMissing: parseRanges("10-20,20-30,30-40"),
+		partialsPresent: parseRanges("0-10"),
+	}
+
+	err := unit.SkipPartials(func(r *block.Range) (bool, error) {
+		return r.StartBlock != 20, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, parseRanges("20-30"), unit.partialsMissing)
+	assert.Equal(t, parseRanges("0-10,10-20,30-40"), unit.partialsPresent)
+}
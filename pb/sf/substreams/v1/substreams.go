@@ -30,11 +30,15 @@ type ModuleOutputData interface {
 func ValidateRequest(req *Request) error {
 	seenMods := map[string]bool{}
 	seenStores := map[string]bool{}
+	seenParams := map[string]bool{}
 	for _, mod := range req.Modules.Modules {
 		seenMods[mod.Name] = true
 		if _, ok := mod.Kind.(*Module_KindStore_); ok {
 			seenStores[mod.Name] = true
 		}
+		if mod.Params != nil {
+			seenParams[mod.Name] = true
+		}
 	}
 
 	for _, outMod := range req.OutputModules {
@@ -52,5 +56,18 @@ func ValidateRequest(req *Request) error {
 		}
 	}
 
+	for modName := range req.Params {
+		if !seenParams[modName] {
+			if seenMods[modName] {
+				return fmt.Errorf("params for module %q: module does not declare 'params'", modName)
+			}
+			return fmt.Errorf("params for module %q: module is not defined in the modules graph", modName)
+		}
+	}
+
+	if _, found := LogLevel_name[int32(req.MinLogLevel)]; !found {
+		return fmt.Errorf("invalid min log level %d", req.MinLogLevel)
+	}
+
 	return nil
 }
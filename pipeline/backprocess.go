@@ -1,15 +1,25 @@
 package pipeline
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
 
 	"github.com/streamingfast/substreams"
+	"github.com/streamingfast/substreams/block"
 	"github.com/streamingfast/substreams/orchestrator"
 	"github.com/streamingfast/substreams/store"
 	"go.uber.org/zap"
 )
 
+// maxBackProcessAttempts bounds the times the back-processing is planned
+// again on corrupted snapshots.
+const maxBackProcessAttempts = 3
+
+// backProcessStores produces the stores up to the request start block. A
+// corrupted snapshot met while loading them is deleted, treated as missing by
+// the work planned again.
 func (p *Pipeline) backProcessStores(
 	workerPool *orchestrator.WorkerPool,
 	storeModules []*pbsubstreams.Module,
@@ -18,6 +28,24 @@ func (p *Pipeline) backProcessStores(
 	defer p.reqCtx.EndSpan(err)
 
 	logger := p.reqCtx.logger.Named("back_process")
+	for attempt := 1; ; attempt++ {
+		out, err = p.synchronizeStores(logger, workerPool, storeModules)
+		if attempt == maxBackProcessAttempts || !errors.Is(err, store.ErrCorruptedSnapshot) {
+			return out, err
+		}
+		logger.Warn("corrupted snapshot deleted, planning the work again", zap.Int("attempt", attempt), zap.Error(err))
+	}
+}
+
+func (p *Pipeline) synchronizeStores(
+	logger *zap.Logger,
+	workerPool *orchestrator.WorkerPool,
+	storeModules []*pbsubstreams.Module,
+) (out map[string]store.Store, err error) {
+	// cancels the jobs of an attempt failing on a corrupted snapshot
+	ctx, cancel := context.WithCancel(p.reqCtx)
+	defer cancel()
+
 	logger.Info("synchronizing stores")
 
 	var storageState *orchestrator.StorageState
@@ -35,6 +63,12 @@ func (p *Pipeline) backProcessStores(
 			return nil, err
 		}
 		workPlan[mod.Name] = orchestrator.SplitWork(mod.Name, p.storeFactory.saveInterval, mod.InitialBlock, p.reqCtx.StartBlockNum(), snapshot)
+
+		if mod.BlockFilter != nil {
+			if err = p.skipUnmatchedPartials(mod, workPlan[mod.Name]); err != nil {
+				return nil, fmt.Errorf("skipping partials of module %q: %w", mod.Name, err)
+			}
+		}
 	}
 
 	logger.Info("work plan ready", zap.Stringer("work_plan", workPlan))
@@ -56,7 +90,7 @@ func (p *Pipeline) backProcessStores(
 	logger.Debug("launching squasher")
 
 	var squasher *orchestrator.Squasher
-	if squasher, err = orchestrator.NewSquasher(p.reqCtx, workPlan, p.storeMap, upToBlock, p.storeFactory.saveInterval, jobsPlanner); err != nil {
+	if squasher, err = orchestrator.NewSquasher(ctx, workPlan, p.storeMap, upToBlock, p.storeFactory.saveInterval, jobsPlanner); err != nil {
 		err = fmt.Errorf("initializing squasher: %w", err)
 		return nil, err
 	}
@@ -75,7 +109,7 @@ func (p *Pipeline) backProcessStores(
 
 	logger.Debug("launching scheduler")
 
-	go scheduler.Launch(p.reqCtx, p.reqCtx.Request().Modules, result)
+	go scheduler.Launch(ctx, p.reqCtx.Request().Modules, result)
 
 	jobCount := jobsPlanner.JobCount()
 	for resultCount := 0; resultCount < jobCount; {
@@ -93,6 +127,8 @@ func (p *Pipeline) backProcessStores(
 				return nil, err
 			}
 			logger.Debug("received result", zap.Int("result_count", resultCount), zap.Int("job_count", jobCount))
+		case err = <-squasher.Failed():
+			return nil, fmt.Errorf("squasher failed: %w", err)
 		}
 	}
 
@@ -107,3 +143,25 @@ func (p *Pipeline) backProcessStores(
 	}
 	return out, nil
 }
+
+// skipUnmatchedPartials writes empty partial stores for the missing ranges
+// where the index files show no block matching the module's filter, instead
+// of scheduling jobs to process them.
+func (p *Pipeline) skipUnmatchedPartials(mod *pbsubstreams.Module, workUnit *orchestrator.WorkUnit) error {
+	return workUnit.SkipPartials(func(r *block.Range) (bool, error) {
+		unmatched, err := p.blockIndexes.unmatchedRange(p.reqCtx, mod.Name, r)
+		if err != nil || !unmatched {
+			return false, err
+		}
+
+		partial, err := p.storeFactory.NewPartialKV(p.moduleHashes.Get(mod.Name), mod, r.StartBlock, p.reqCtx.logger)
+		if err != nil {
+			return false, fmt.Errorf("creating partial store: %w", err)
+		}
+		if _, err := partial.Save(p.reqCtx, r.ExclusiveEndBlock); err != nil {
+			return false, fmt.Errorf("saving empty partial store: %w", err)
+		}
+		p.reqCtx.logger.Info("no block matching filter, skipped range", zap.String("module", mod.Name), zap.Object("range", r))
+		return true, nil
+	})
+}
@@ -25,6 +25,9 @@ func (p *Pipeline) StreamEndedWithErr(streamSrv pbsubstreams.Stream_BlocksServer
 		if err := p.FlushStores(p.reqCtx.StopBlockNum()); err != nil {
 			return errors2.NewBasicErr(status.Errorf(codes.Internal, "handling store save boundaries: %s", err), err)
 		}
+		if err := p.blockIndexes.flush(p.reqCtx, p.reqCtx.StopBlockNum()); err != nil {
+			return errors2.NewBasicErr(status.Errorf(codes.Internal, "saving block indexes: %s", err), err)
+		}
 	}
 
 	if errors.Is(err, io.EOF) || errors.Is(err, stream.ErrStopBlockReached) {
@@ -3,9 +3,9 @@ package cachev1
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"go.uber.org/zap/zapcore"
+	"io"
 	"math"
 	"sort"
 	"strconv"
@@ -16,6 +16,7 @@ import (
 	"github.com/streamingfast/derr"
 	"github.com/streamingfast/dstore"
 	"github.com/streamingfast/substreams/block"
+	"github.com/streamingfast/substreams/compress"
 	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
 	"github.com/streamingfast/substreams/utils"
 	"go.uber.org/zap"
@@ -38,9 +39,14 @@ type OutputCache struct {
 	moduleName        string
 	currentBlockRange *block.Range
 	kv                outputKV
+	byBlockNum        map[uint64]*CacheItem // index of `kv`, see `setKV`
 	store             dstore.Store
 	saveBlockInterval uint64
+	codec             SegmentCodec
+	compression       compress.Compression
 	logger            *zap.Logger
+
+	prefetched *prefetchedSegment
 }
 
 func NewOutputCache(moduleName string, store dstore.Store, saveBlockInterval uint64, logger *zap.Logger) *OutputCache {
@@ -48,15 +54,40 @@ func NewOutputCache(moduleName string, store dstore.Store, saveBlockInterval uin
 		moduleName:        moduleName,
 		store:             store,
 		saveBlockInterval: saveBlockInterval,
+		codec:             JSONCodec,
+		compression:       compress.Default,
 		logger:            logger.Named("cache").With(zap.String("module_name", moduleName)),
 	}
 }
 
+// SetCodec sets the format of the files saved and loaded afterwards.
+func (c *OutputCache) SetCodec(codec SegmentCodec) {
+	c.codec = codec
+}
+
+// SetCompression sets the compression of the files saved afterwards. Files
+// are loaded whatever their compression.
+func (c *OutputCache) SetCompression(compression compress.Compression) {
+	c.compression = compression
+}
+
+// setKV replaces the outputs held by `kv`, indexing them by block number.
+func (c *OutputCache) setKV(kv outputKV) {
+	c.kv = kv
+	c.byBlockNum = make(map[uint64]*CacheItem, len(kv))
+	for _, item := range kv {
+		c.byBlockNum[item.BlockNum] = item
+	}
+}
+
 func (c *OutputCache) currentFilename() string {
 	return ComputeDBinFilename(c.currentBlockRange.StartBlock, c.currentBlockRange.ExclusiveEndBlock)
 }
 
 func (c *OutputCache) SortedCacheItems() (out []*CacheItem) {
+	c.RLock()
+	defer c.RUnlock()
+
 	for _, item := range c.kv {
 		out = append(out, item)
 	}
@@ -86,6 +117,7 @@ func (c *OutputCache) Set(clock *pbsubstreams.Clock, cursor string, data []byte)
 	}
 
 	c.kv[clock.Id] = ci
+	c.byBlockNum[clock.Number] = ci
 
 	return nil
 }
@@ -107,19 +139,26 @@ func (c *OutputCache) GetAtBlock(blockNumber uint64) ([]byte, bool) {
 	c.Lock()
 	defer c.Unlock()
 
-	for _, value := range c.kv {
-		if value.BlockNum == blockNumber {
-			return value.Payload, true
-		}
+	item, found := c.byBlockNum[blockNumber]
+	if !found {
+		return nil, false
 	}
-
-	return nil, false
+	return item.Payload, true
 }
 
 func (c *OutputCache) LoadAtBlock(ctx context.Context, atBlock uint64) (found bool, err error) {
 	c.logger.Info("loading cache at block", zap.Uint64("at_block_num", atBlock))
 
-	c.kv = make(outputKV)
+	if p := c.takePrefetched(ctx, atBlock); p != nil {
+		c.Lock()
+		c.setKV(p.kv)
+		c.currentBlockRange = p.blockRange
+		c.Unlock()
+		c.logger.Debug("outputs data prefetched", zap.Int("output_count", len(p.kv)), zap.Stringer("block_range", p.blockRange))
+		return true, nil
+	}
+
+	c.setKV(make(outputKV))
 
 	blockRange, found, err := findBlockRange(ctx, c.store, atBlock)
 	if err != nil {
@@ -143,44 +182,73 @@ func (c *OutputCache) LoadAtBlock(ctx context.Context, atBlock uint64) (found bo
 }
 func (c *OutputCache) Load(ctx context.Context, blockRange *block.Range) error {
 	c.logger.Debug("loading cache", zap.Object("range", blockRange))
-	c.kv = make(outputKV)
+	c.setKV(make(outputKV))
 
+	kv, err := c.readFile(ctx, blockRange)
+	if err != nil {
+		return err
+	}
+	c.setKV(kv)
+
+	c.currentBlockRange = blockRange
+	c.logger.Debug("outputs data loaded", zap.Int("output_count", len(c.kv)), zap.Stringer("block_range", c.currentBlockRange))
+	return nil
+}
+
+// readFile loads the segment of `blockRange`. Failing to read the file is
+// retried, not failing to decode it.
+func (c *OutputCache) readFile(ctx context.Context, blockRange *block.Range) (outputKV, error) {
 	filename := ComputeDBinFilename(blockRange.StartBlock, blockRange.ExclusiveEndBlock)
 	c.logger.Debug("loading outputs data", zap.String("file_name", filename), zap.Object("block_range", blockRange))
 
+	var data []byte
 	err := derr.RetryContext(ctx, 3, func(ctx context.Context) error {
 		objectReader, err := c.store.OpenObject(ctx, filename)
 		if err != nil {
 			return fmt.Errorf("loading block reader %s: %w", filename, err)
 		}
+		defer objectReader.Close()
 
-		if err = json.NewDecoder(objectReader).Decode(&c.kv); err != nil {
-			return fmt.Errorf("json decoding file %s: %w", filename, err)
+		if data, err = io.ReadAll(objectReader); err != nil {
+			return fmt.Errorf("reading file %s: %w", filename, err)
 		}
-
 		return nil
 	})
 	if err != nil {
-		return fmt.Errorf("retried: %w", err)
+		return nil, fmt.Errorf("retried: %w", err)
 	}
 
-	c.currentBlockRange = blockRange
-	c.logger.Debug("outputs data loaded", zap.Int("output_count", len(c.kv)), zap.Stringer("block_range", c.currentBlockRange))
-	return nil
+	data, err = compress.Decompress(data)
+	if err != nil {
+		return nil, fmt.Errorf("decompressing file %s: %w", filename, err)
+	}
+	items, err := c.codec.Unmarshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("decoding file %s: %w", filename, err)
+	}
+
+	kv := make(outputKV, len(items))
+	for _, item := range items {
+		kv[item.BlockID] = item
+	}
+	return kv, nil
 }
 
-func (c *OutputCache) save(ctx context.Context, filename string) error {
+func (c *OutputCache) save(filename string) error {
 	c.logger.Info("saving cache", zap.Stringer("block_range", c.currentBlockRange), zap.String("filename", filename))
 
-	buffer := bytes.NewBuffer(nil)
-	err := json.NewEncoder(buffer).Encode(c.kv)
+	data, err := c.codec.Marshal(c.currentBlockRange, c.SortedCacheItems())
 	if err != nil {
-		return fmt.Errorf("json encoding outputs: %w", err)
+		return err
+	}
+	cnt, err := c.compression.Compress(data)
+	if err != nil {
+		return fmt.Errorf("compressing outputs with %s: %w", c.compression, err)
 	}
-	cnt := buffer.Bytes()
 
+	// written past the end of the request
 	go func() {
-		err = derr.RetryContext(ctx, 3, func(ctx context.Context) error {
+		err = derr.RetryContext(context.Background(), 3, func(ctx context.Context) error {
 			reader := bytes.NewReader(cnt)
 			return c.store.WriteObject(ctx, filename, reader)
 		})
@@ -234,7 +302,23 @@ func (c *OutputCache) Delete(blockID string) {
 	c.Lock()
 	defer c.Unlock()
 
+	item, found := c.kv[blockID]
+	if !found {
+		return
+	}
 	delete(c.kv, blockID)
+
+	if c.byBlockNum[item.BlockNum] != item {
+		return
+	}
+	delete(c.byBlockNum, item.BlockNum)
+	// another fork of the block may remain
+	for _, other := range c.kv {
+		if other.BlockNum == item.BlockNum {
+			c.byBlockNum[item.BlockNum] = other
+			break
+		}
+	}
 }
 
 func (c *OutputCache) MarshalLogObject(enc zapcore.ObjectEncoder) error {
@@ -1,11 +1,18 @@
 package cachev1
 
 import (
+	"bytes"
+	"context"
+	"io"
 	"testing"
 
+	"github.com/streamingfast/dstore"
 	"github.com/streamingfast/logging"
+	"go.uber.org/zap"
 
 	"github.com/streamingfast/substreams/block"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -114,7 +121,7 @@ func TestOutputCache_Delete(t *testing.T) {
 	for _, test := range testCases {
 		t.Run(test.name, func(t *testing.T) {
 			outputCache := NewOutputCache("module1", nil, 10, zlog)
-			outputCache.kv = test.kv
+			outputCache.setKV(test.kv)
 			for _, key := range test.keysToDelete {
 				outputCache.Delete(key)
 			}
@@ -122,3 +129,44 @@ func TestOutputCache_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestOutputCache_GetAtBlock(t *testing.T) {
+	outputCache := NewOutputCache("module1", nil, 10, zap.NewNop())
+	outputCache.setKV(outputKV{
+		"1a": {BlockNum: 1, BlockID: "1a", Payload: []byte("out1")},
+		"2a": {BlockNum: 2, BlockID: "2a", Payload: []byte("out2a")},
+	})
+	require.NoError(t, outputCache.Set(&pbsubstreams.Clock{Id: "2b", Number: 2}, "", []byte("out2b")))
+
+	payload, found := outputCache.GetAtBlock(1)
+	require.True(t, found)
+	require.Equal(t, "out1", string(payload))
+
+	payload, found = outputCache.GetAtBlock(2)
+	require.True(t, found)
+	require.Equal(t, "out2b", string(payload))
+
+	// the other fork of the block is found once one is deleted
+	outputCache.Delete("2b")
+	payload, found = outputCache.GetAtBlock(2)
+	require.True(t, found)
+	require.Equal(t, "out2a", string(payload))
+
+	outputCache.Delete("2a")
+	_, found = outputCache.GetAtBlock(2)
+	require.False(t, found)
+}
+
+func TestOutputCache_Load_decodeError(t *testing.T) {
+	openCalls := 0
+	store := dstore.NewMockStore(nil)
+	store.OpenObjectFunc = func(ctx context.Context, name string) (io.ReadCloser, error) {
+		openCalls++
+		return io.NopCloser(bytes.NewReader([]byte("corrupted"))), nil
+	}
+
+	cache := NewOutputCache("mod", store, 10, zap.NewNop())
+	err := cache.Load(context.Background(), block.NewRange(10, 20))
+	assert.ErrorContains(t, err, "decoding file 0000000010-0000000020.output")
+	assert.Equal(t, 1, openCalls, "decoding errors are not retried")
+}
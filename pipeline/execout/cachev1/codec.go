@@ -0,0 +1,49 @@
+package cachev1
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/streamingfast/substreams/block"
+)
+
+// SegmentCodec encodes the outputs of a segment in its file, the one thing
+// telling the output cache formats apart. Items are sorted by block number.
+type SegmentCodec interface {
+	Marshal(blockRange *block.Range, items []*CacheItem) ([]byte, error)
+	Unmarshal(data []byte) ([]*CacheItem, error)
+}
+
+// JSONCodec writes the segments as a JSON object of their items by block ID.
+var JSONCodec SegmentCodec = jsonCodec{}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(_ *block.Range, items []*CacheItem) ([]byte, error) {
+	kv := make(outputKV, len(items))
+	for _, item := range items {
+		kv[item.BlockID] = item
+	}
+	data, err := json.Marshal(kv)
+	if err != nil {
+		return nil, fmt.Errorf("json encoding outputs: %w", err)
+	}
+	return data, nil
+}
+
+func (jsonCodec) Unmarshal(data []byte) ([]*CacheItem, error) {
+	kv := outputKV{}
+	if err := json.Unmarshal(data, &kv); err != nil {
+		return nil, fmt.Errorf("json decoding outputs: %w", err)
+	}
+
+	items := make([]*CacheItem, 0, len(kv))
+	for _, item := range kv {
+		items = append(items, item)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].BlockNum < items[j].BlockNum
+	})
+	return items, nil
+}
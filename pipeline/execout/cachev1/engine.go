@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/streamingfast/bstream"
 	"github.com/streamingfast/dstore"
+	"github.com/streamingfast/substreams/compress"
 	"github.com/streamingfast/substreams/manifest"
 	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
 	"github.com/streamingfast/substreams/pipeline/execout"
@@ -22,8 +23,12 @@ type Engine struct {
 	ctx               context.Context
 	caches            map[string]*OutputCacheState
 	SaveBlockInterval uint64
+	codec             SegmentCodec
+	compression       compress.Compression
 	baseCacheStore    dstore.Store
 	logger            *zap.Logger
+
+	prefetchThreshold float64
 }
 
 type OutputCacheState struct {
@@ -31,16 +36,33 @@ type OutputCacheState struct {
 	initialized bool
 }
 
-func NewEngine(ctx context.Context, saveBlockInterval uint64, baseCacheStore dstore.Store, logger *zap.Logger) (execout.CacheEngine, error) {
+// NewEngine returns an engine writing and reading the segments of the output
+// caches as JSON. Loading segments, prefetched or not, stops with `ctx`, the
+// request context, not writing them.
+func NewEngine(ctx context.Context, saveBlockInterval uint64, compression compress.Compression, baseCacheStore dstore.Store, logger *zap.Logger) (execout.CacheEngine, error) {
+	return NewEngineWithCodec(ctx, saveBlockInterval, JSONCodec, compression, baseCacheStore, logger)
+}
+
+// NewEngineWithCodec returns an engine writing and reading the segments of
+// the output caches with `codec`.
+func NewEngineWithCodec(ctx context.Context, saveBlockInterval uint64, codec SegmentCodec, compression compress.Compression, baseCacheStore dstore.Store, logger *zap.Logger) (execout.CacheEngine, error) {
 	e := &Engine{
 		ctx:               ctx,
 		caches:            make(map[string]*OutputCacheState),
 		SaveBlockInterval: saveBlockInterval,
+		codec:             codec,
+		compression:       compression,
 		baseCacheStore:    baseCacheStore,
 		logger:            logger,
 	}
 	return e, nil
 }
+
+// EnablePrefetch implements `execout.Prefetcher`.
+func (e *Engine) EnablePrefetch(threshold float64) {
+	e.prefetchThreshold = threshold
+}
+
 func (e *Engine) Init(modules *manifest.ModuleHashes) error {
 	return modules.Iter(func(hash, name string) error {
 		if err := e.registerCache(name, hash); err != nil {
@@ -51,7 +73,11 @@ func (e *Engine) Init(modules *manifest.ModuleHashes) error {
 }
 func (e *Engine) NewBlock(blockRef bstream.BlockRef, step bstream.StepType) error {
 	if step.Matches(bstream.StepIrreversible) {
-		return e.flushCaches(blockRef)
+		if err := e.flushCaches(blockRef); err != nil {
+			return err
+		}
+		e.prefetchCaches(blockRef)
+		return nil
 	}
 	if step.Matches(bstream.StepUndo) {
 		e.undoCaches(blockRef)
@@ -63,7 +89,7 @@ func (e *Engine) flushCaches(blockRef bstream.BlockRef) error {
 	for name, cache := range e.caches {
 		if cache.c.IsOutOfRange(blockRef) {
 			e.logger.Debug("saving cache", zap.Object("cache", cache.c))
-			if err := cache.c.save(e.ctx, cache.c.currentFilename()); err != nil {
+			if err := cache.c.save(cache.c.currentFilename()); err != nil {
 				return fmt.Errorf("save: saving outpust or module kv %s: %w", name, err)
 			}
 
@@ -75,6 +101,24 @@ func (e *Engine) flushCaches(blockRef bstream.BlockRef) error {
 	return nil
 }
 
+// prefetchCaches starts loading the next segment of the caches past the
+// prefetch threshold of their current one.
+func (e *Engine) prefetchCaches(blockRef bstream.BlockRef) {
+	if e.prefetchThreshold <= 0 {
+		return
+	}
+	for _, cache := range e.caches {
+		if !cache.initialized {
+			continue
+		}
+		current := cache.c.currentBlockRange
+		trigger := current.StartBlock + uint64(float64(current.Len())*e.prefetchThreshold)
+		if blockRef.Num() >= trigger && current.Contains(blockRef.Num()) {
+			cache.c.Prefetch(e.ctx, current.ExclusiveEndBlock)
+		}
+	}
+}
+
 func (e *Engine) undoCaches(blockRef bstream.BlockRef) error {
 	for _, cache := range e.caches {
 		cache.c.Delete(blockRef.ID())
@@ -100,8 +144,12 @@ func (e *Engine) registerCache(moduleName, moduleHash string) error {
 		return fmt.Errorf("failed createing substore: %w", err)
 	}
 
+	outputCache := NewOutputCache(moduleName, moduleStore, e.SaveBlockInterval, e.logger)
+	outputCache.SetCodec(e.codec)
+	outputCache.SetCompression(e.compression)
+
 	e.caches[moduleName] = &OutputCacheState{
-		c:           NewOutputCache(moduleName, moduleStore, e.SaveBlockInterval, e.logger),
+		c:           outputCache,
 		initialized: false,
 	}
 	return nil
@@ -0,0 +1,76 @@
+package cachev1
+
+import (
+	"context"
+
+	"github.com/streamingfast/substreams/block"
+	"go.uber.org/zap"
+)
+
+// prefetchedSegment is the segment starting at `atBlock`, loaded in the
+// background, ready once `done` is closed.
+type prefetchedSegment struct {
+	atBlock uint64
+	cancel  context.CancelFunc
+	done    chan struct{}
+
+	blockRange *block.Range
+	kv         outputKV
+	found      bool
+	err        error
+}
+
+// Prefetch loads the segment starting at `atBlock` in the background, for
+// the next `LoadAtBlock` at that block, until `ctx` is canceled. It replaces
+// a prefetch of another segment.
+func (c *OutputCache) Prefetch(ctx context.Context, atBlock uint64) {
+	if c.prefetched != nil {
+		if c.prefetched.atBlock == atBlock {
+			return
+		}
+		c.prefetched.cancel()
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	p := &prefetchedSegment{atBlock: atBlock, cancel: cancel, done: make(chan struct{})}
+	c.prefetched = p
+	c.logger.Debug("prefetching cache", zap.Uint64("at_block_num", atBlock))
+
+	go func() {
+		defer close(p.done)
+		p.blockRange, p.found, p.err = findBlockRange(ctx, c.store, atBlock)
+		if p.err != nil || !p.found {
+			return
+		}
+		p.kv, p.err = c.readFile(ctx, p.blockRange)
+	}()
+}
+
+// takePrefetched returns the segment starting at `atBlock` if it was
+// prefetched, waiting for its load to complete. Segments which failed to
+// load, or were not found yet, are left to `LoadAtBlock` to look up again.
+func (c *OutputCache) takePrefetched(ctx context.Context, atBlock uint64) *prefetchedSegment {
+	p := c.prefetched
+	if p == nil {
+		return nil
+	}
+	c.prefetched = nil
+	defer p.cancel()
+
+	if p.atBlock != atBlock {
+		return nil
+	}
+	select {
+	case <-p.done:
+	case <-ctx.Done():
+		return nil
+	}
+	if p.err != nil {
+		c.logger.Warn("failed prefetching cache, loading it again", zap.Uint64("at_block_num", atBlock), zap.Error(p.err))
+		return nil
+	}
+	if !p.found {
+		return nil
+	}
+	return p
+}
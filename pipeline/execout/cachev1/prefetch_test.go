@@ -0,0 +1,101 @@
+package cachev1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/streamingfast/bstream"
+	"github.com/streamingfast/dstore"
+	"github.com/streamingfast/substreams/block"
+	"github.com/streamingfast/substreams/compress"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func writeTestSegment(t *testing.T, store dstore.Store, r *block.Range, kv outputKV) {
+	t.Helper()
+	data, err := json.Marshal(kv)
+	require.NoError(t, err)
+	cnt, err := compress.Default.Compress(data)
+	require.NoError(t, err)
+	require.NoError(t, store.WriteObject(context.Background(), ComputeDBinFilename(r.StartBlock, r.ExclusiveEndBlock), bytes.NewReader(cnt)))
+}
+
+func TestOutputCache_Prefetch(t *testing.T) {
+	ctx := context.Background()
+	store, err := dstore.NewStore("file://"+t.TempDir(), "", "", false)
+	require.NoError(t, err)
+	writeTestSegment(t, store, block.NewRange(10, 20), outputKV{"12a": {BlockNum: 12, BlockID: "12a", Payload: []byte("out12")}})
+
+	cache := NewOutputCache("mod", store, 10, zap.NewNop())
+	cache.Prefetch(ctx, 10)
+	<-cache.prefetched.done
+	require.NoError(t, store.DeleteObject(ctx, ComputeDBinFilename(10, 20)))
+
+	found, err := cache.LoadAtBlock(ctx, 10)
+	require.NoError(t, err)
+	require.True(t, found, "prefetched before the file was deleted")
+	assert.Nil(t, cache.prefetched)
+	assert.Equal(t, block.NewRange(10, 20), cache.currentBlockRange)
+	out, found := cache.Get(&pbsubstreams.Clock{Number: 12, Id: "12a"})
+	require.True(t, found)
+	assert.Equal(t, "out12", string(out))
+
+	// a canceled prefetch is loaded again
+	writeTestSegment(t, store, block.NewRange(20, 30), outputKV{"21a": {BlockNum: 21, BlockID: "21a"}})
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	cache.Prefetch(canceled, 20)
+	found, err = cache.LoadAtBlock(ctx, 20)
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Len(t, cache.kv, 1)
+}
+
+func TestOutputCache_takePrefetched_canceled(t *testing.T) {
+	store := dstore.NewMockStore(nil)
+	store.ListFilesFunc = func(ctx context.Context, prefix string, max int) ([]string, error) {
+		return []string{ComputeDBinFilename(10, 20)}, nil
+	}
+	release := make(chan struct{})
+	defer close(release)
+	store.OpenObjectFunc = func(ctx context.Context, name string) (io.ReadCloser, error) {
+		<-release
+		return nil, fmt.Errorf("released")
+	}
+
+	cache := NewOutputCache("mod", store, 10, zap.NewNop())
+	cache.Prefetch(context.Background(), 10)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Nil(t, cache.takePrefetched(ctx, 10), "wait canceled with the request")
+}
+
+func TestEngine_prefetchCaches(t *testing.T) {
+	ctx := context.Background()
+	store, err := dstore.NewStore("file://"+t.TempDir(), "", "", false)
+	require.NoError(t, err)
+
+	cache := NewOutputCache("mod", store, 10, zap.NewNop())
+	_, err = cache.LoadAtBlock(ctx, 10)
+	require.NoError(t, err)
+	e := &Engine{ctx: ctx, caches: map[string]*OutputCacheState{"mod": {c: cache, initialized: true}}, logger: zap.NewNop()}
+
+	e.prefetchCaches(bstream.NewBlockRef("18a", 18))
+	assert.Nil(t, cache.prefetched, "prefetch disabled")
+
+	e.EnablePrefetch(0.8)
+	e.prefetchCaches(bstream.NewBlockRef("17a", 17))
+	assert.Nil(t, cache.prefetched)
+	e.prefetchCaches(bstream.NewBlockRef("18a", 18))
+	require.NotNil(t, cache.prefetched)
+	assert.Equal(t, uint64(20), cache.prefetched.atBlock)
+	<-cache.prefetched.done
+}
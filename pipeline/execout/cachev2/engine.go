@@ -0,0 +1,17 @@
+package cachev2
+
+import (
+	"context"
+
+	"github.com/streamingfast/dstore"
+	"github.com/streamingfast/substreams/compress"
+	"github.com/streamingfast/substreams/pipeline/execout"
+	"github.com/streamingfast/substreams/pipeline/execout/cachev1"
+	"go.uber.org/zap"
+)
+
+// NewEngine returns the `cachev1` engine writing the segments with `Codec`,
+// as protobuf, still reading the JSON ones.
+func NewEngine(ctx context.Context, saveBlockInterval uint64, compression compress.Compression, baseCacheStore dstore.Store, logger *zap.Logger) (execout.CacheEngine, error) {
+	return cachev1.NewEngineWithCodec(ctx, saveBlockInterval, Codec, compression, baseCacheStore, logger)
+}
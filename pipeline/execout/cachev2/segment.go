@@ -0,0 +1,74 @@
+package cachev2
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/streamingfast/substreams/block"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/streamingfast/substreams/pipeline/execout/cachev1"
+	"google.golang.org/protobuf/proto"
+)
+
+// Segments start with `segmentMagic`, followed by a
+// `pbsubstreams.OutputCacheSegment`. Files not starting with the magic are
+// the legacy `cachev1` JSON ones, still read.
+var segmentMagic = []byte("\x00sfoutput")
+
+const segmentVersion = 1
+
+// Codec writes the segments as protobuf, indexed by block number, reading
+// both the protobuf and the `cachev1` JSON ones.
+var Codec cachev1.SegmentCodec = protobufCodec{}
+
+type protobufCodec struct{}
+
+func (protobufCodec) Marshal(blockRange *block.Range, items []*cachev1.CacheItem) ([]byte, error) {
+	segment := &pbsubstreams.OutputCacheSegment{
+		Version:           segmentVersion,
+		StartBlock:        blockRange.StartBlock,
+		ExclusiveEndBlock: blockRange.ExclusiveEndBlock,
+		Items:             make([]*pbsubstreams.OutputCacheItem, len(items)),
+	}
+	for i, item := range items {
+		segment.Items[i] = &pbsubstreams.OutputCacheItem{
+			BlockNum:  item.BlockNum,
+			BlockId:   item.BlockID,
+			Timestamp: item.Timestamp,
+			Cursor:    item.Cursor,
+			Payload:   item.Payload,
+		}
+	}
+
+	data, err := proto.MarshalOptions{}.MarshalAppend(append([]byte{}, segmentMagic...), segment)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling segment: %w", err)
+	}
+	return data, nil
+}
+
+func (protobufCodec) Unmarshal(data []byte) ([]*cachev1.CacheItem, error) {
+	if !bytes.HasPrefix(data, segmentMagic) {
+		return cachev1.JSONCodec.Unmarshal(data)
+	}
+
+	segment := &pbsubstreams.OutputCacheSegment{}
+	if err := proto.Unmarshal(data[len(segmentMagic):], segment); err != nil {
+		return nil, fmt.Errorf("unmarshalling segment: %w", err)
+	}
+	if segment.Version > segmentVersion {
+		return nil, fmt.Errorf("unsupported segment version %d, expected %d at most", segment.Version, segmentVersion)
+	}
+
+	items := make([]*cachev1.CacheItem, len(segment.Items))
+	for i, item := range segment.Items {
+		items[i] = &cachev1.CacheItem{
+			BlockNum:  item.BlockNum,
+			BlockID:   item.BlockId,
+			Timestamp: item.Timestamp,
+			Cursor:    item.Cursor,
+			Payload:   item.Payload,
+		}
+	}
+	return items, nil
+}
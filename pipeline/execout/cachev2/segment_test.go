@@ -0,0 +1,89 @@
+package cachev2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/streamingfast/dstore"
+	"github.com/streamingfast/substreams/block"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/streamingfast/substreams/pipeline/execout/cachev1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func newTestStore(t *testing.T) dstore.Store {
+	t.Helper()
+	store, err := dstore.NewStore("file://"+t.TempDir(), "", "", false)
+	require.NoError(t, err)
+	return store
+}
+
+func clock(num uint64, id string) *pbsubstreams.Clock {
+	return &pbsubstreams.Clock{Number: num, Id: id, Timestamp: timestamppb.New(time.Unix(int64(num), 0))}
+}
+
+func TestCodec(t *testing.T) {
+	items := []*cachev1.CacheItem{
+		{BlockNum: 21, BlockID: "21a"},
+		{BlockNum: 22, BlockID: "22a", Payload: []byte("out22"), Timestamp: timestamppb.New(time.Unix(22, 0)), Cursor: "cursor22"},
+	}
+	data, err := Codec.Marshal(block.NewRange(20, 30), items)
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(data, segmentMagic))
+
+	decoded, err := Codec.Unmarshal(data)
+	require.NoError(t, err)
+	require.Len(t, decoded, 2)
+	assert.Equal(t, "21a", decoded[0].BlockID)
+	assert.Equal(t, "out22", string(decoded[1].Payload))
+	assert.Equal(t, "cursor22", decoded[1].Cursor)
+	assert.Equal(t, int64(22), decoded[1].Timestamp.Seconds)
+
+	_, err = Codec.Unmarshal(append(append([]byte{}, segmentMagic...), 0xff))
+	assert.Error(t, err)
+	_, err = Codec.Unmarshal([]byte("garbage"))
+	assert.Error(t, err)
+}
+
+func TestOutputCache_load(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	data, err := Codec.Marshal(block.NewRange(20, 30), []*cachev1.CacheItem{{BlockNum: 22, BlockID: "22a", Payload: []byte("out22")}})
+	require.NoError(t, err)
+	require.NoError(t, store.WriteObject(ctx, cachev1.ComputeDBinFilename(20, 30), bytes.NewReader(data)))
+
+	legacy, err := json.Marshal(map[string]*cachev1.CacheItem{
+		"11a": {BlockNum: 11, BlockID: "11a", Payload: []byte("out11"), Timestamp: timestamppb.New(time.Unix(11, 0)), Cursor: "cursor11"},
+		"10a": {BlockNum: 10, BlockID: "10a", Payload: []byte("out10"), Cursor: "cursor10"},
+	})
+	require.NoError(t, err)
+	require.NoError(t, store.WriteObject(ctx, cachev1.ComputeDBinFilename(10, 20), bytes.NewReader(legacy)))
+
+	cache := cachev1.NewOutputCache("mod", store, 10, zap.NewNop())
+	cache.SetCodec(Codec)
+
+	found, err := cache.LoadAtBlock(ctx, 20)
+	require.NoError(t, err)
+	require.True(t, found)
+	out, found := cache.Get(clock(22, "22a"))
+	require.True(t, found)
+	assert.Equal(t, "out22", string(out))
+	_, found = cache.Get(clock(22, "22b"))
+	assert.False(t, found, "output of a forked block")
+
+	found, err = cache.LoadAtBlock(ctx, 10)
+	require.NoError(t, err)
+	require.True(t, found, "legacy JSON segment")
+	items := cache.SortedCacheItems()
+	require.Len(t, items, 2)
+	assert.Equal(t, "10a", items[0].BlockID)
+	assert.Equal(t, "cursor11", items[1].Cursor)
+	assert.Equal(t, int64(11), items[1].Timestamp.Seconds)
+}
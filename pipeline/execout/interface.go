@@ -20,6 +20,20 @@ type CacheEngine interface {
 	//FlushAndUpdate(ctx context.Context, blockRef bstream.BlockRef) error
 }
 
+// DefaultPrefetchThreshold is the fraction of their current segment of
+// outputs after which the caches prefetch the next one, used by the service.
+const DefaultPrefetchThreshold = 0.8
+
+// Prefetcher is implemented by the cache engines able to load the next
+// segment of outputs in the background, before the blocks reach it.
+type Prefetcher interface {
+	// EnablePrefetch makes the caches load their next segment once the
+	// blocks processed pass `threshold`, a fraction of their current
+	// segment, until the context of the engine is canceled. A threshold of 0
+	// disables it.
+	EnablePrefetch(threshold float64)
+}
+
 type ExecutionOutputGetter interface {
 	Clock() *pbsubstreams.Clock
 	Get(name string) (value []byte, cached bool, err error)
@@ -10,13 +10,14 @@ import (
 type ExecOutputMap struct {
 	values map[string][]byte
 	clock  *pbsubstreams.Clock
+
+	// the block payload is only read when a module needs it, not for the
+	// blocks skipped by all modules
+	blockType string
+	block     *bstream.Block
 }
 
 func NewExecOutputMap(blockType string, block *bstream.Block, clock *pbsubstreams.Clock) (*ExecOutputMap, error) {
-	blkBytes, err := block.Payload.Get()
-	if err != nil {
-		return nil, fmt.Errorf("getting block %d %q: %w", block.Number, block.Id, err)
-	}
 	clockBytes, err := proto.Marshal(clock)
 	if err != nil {
 		return nil, fmt.Errorf("getting block %d %q: %w", block.Number, block.Id, err)
@@ -25,9 +26,10 @@ func NewExecOutputMap(blockType string, block *bstream.Block, clock *pbsubstream
 	return &ExecOutputMap{
 		clock: clock,
 		values: map[string][]byte{
-			blockType:                blkBytes,
 			"sf.substreams.v1.Clock": clockBytes,
 		},
+		blockType: blockType,
+		block:     block,
 	}, nil
 }
 
@@ -36,6 +38,15 @@ func (i *ExecOutputMap) Clock() *pbsubstreams.Clock {
 }
 
 func (i *ExecOutputMap) Get(moduleName string) (value []byte, cached bool, err error) {
+	if moduleName == i.blockType && i.block != nil {
+		blkBytes, err := i.block.Payload.Get()
+		if err != nil {
+			return nil, false, fmt.Errorf("getting block %d %q: %w", i.block.Number, i.block.Id, err)
+		}
+		i.values[i.blockType] = blkBytes
+		i.block = nil
+	}
+
 	val, found := i.values[moduleName]
 	if !found {
 		return nil, false, NotFound
@@ -3,7 +3,9 @@ package pipeline
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"github.com/streamingfast/substreams/metrics"
 	"github.com/streamingfast/substreams/pipeline/execout"
 
 	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
@@ -19,6 +21,7 @@ import (
 type ErrorExecutor struct {
 	message    string
 	stackTrace []string
+	cause      error
 }
 
 func (e *ErrorExecutor) Error() string {
@@ -38,6 +41,10 @@ func (e *ErrorExecutor) Error() string {
 	return b.String()
 }
 
+func (e *ErrorExecutor) Unwrap() error {
+	return e.cause
+}
+
 type ModuleExecutor interface {
 	// Name returns the name of the module as defined in the manifest.
 	Name() string
@@ -51,7 +58,7 @@ type ModuleExecutor interface {
 	run(ctx context.Context, reader execout.ExecutionOutputGetter) (out []byte, moduleOutputData pbsubstreams.ModuleOutputData, err error)
 	applyCachedOutput(value []byte) error
 
-	moduleLogs() (logs []string, truncated bool)
+	moduleLogs() (logs []string, structuredLogs []*pbsubstreams.ModuleLog, truncated bool)
 	currentExecutionStack() []string
 }
 
@@ -61,11 +68,12 @@ type BaseExecutor struct {
 	wasmArguments []wasm.Argument
 	entrypoint    string
 	tracer        ttrace.Tracer
+	fuelBudget    *fuelBudget
 }
 
-func (e *BaseExecutor) moduleLogs() (logs []string, truncated bool) {
+func (e *BaseExecutor) moduleLogs() (logs []string, structuredLogs []*pbsubstreams.ModuleLog, truncated bool) {
 	if instance := e.wasmModule.CurrentInstance; instance != nil {
-		return instance.Logs, instance.ReachedLogsMaxByteCount()
+		return instance.Logs, instance.StructuredLogs, instance.ReachedLogsMaxByteCount()
 	}
 	return
 }
@@ -104,6 +112,7 @@ func (e *MapperModuleExecutor) run(ctx context.Context, reader execout.Execution
 
 	if instance != nil {
 		out = instance.Output()
+		e.traceFuel(span, instance)
 	}
 
 	if out != nil {
@@ -118,7 +127,7 @@ func (e *MapperModuleExecutor) run(ctx context.Context, reader execout.Execution
 
 type StoreModuleExecutor struct {
 	BaseExecutor
-	outputStore store.DeltaAccessor
+	outputStore store.Store
 }
 
 func (e *StoreModuleExecutor) Name() string { return e.moduleName }
@@ -143,10 +152,18 @@ func (e *StoreModuleExecutor) run(ctx context.Context, reader execout.ExecutionO
 	span.SetAttributes(attribute.String("module", e.moduleName))
 	defer span.End()
 
-	if _, err := e.wasmCall(reader); err != nil {
+	instance, err := e.wasmCall(reader)
+	if err != nil {
 		span.SetStatus(codes.Error, err.Error())
 		return nil, nil, fmt.Errorf("failed to run store wasm call: %w", err)
 	}
+	if instance != nil {
+		e.traceFuel(span, instance)
+	}
+	if err := e.outputStore.Err(); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, nil, fmt.Errorf("writing store: %w", err)
+	}
 
 	deltas := &pbsubstreams.StoreDeltas{
 		Deltas: e.outputStore.GetDeltas(),
@@ -166,6 +183,12 @@ func (e *StoreModuleExecutor) run(ctx context.Context, reader execout.ExecutionO
 	return data, moduleOutput, nil
 }
 
+func (e *BaseExecutor) traceFuel(span ttrace.Span, instance *wasm.Instance) {
+	if e.fuelBudget != nil {
+		span.SetAttributes(attribute.Int64("fuel_consumed", int64(instance.FuelConsumed)))
+	}
+}
+
 func (e *BaseExecutor) wasmCall(reader execout.ExecutionOutputGetter) (instance *wasm.Instance, err error) {
 	hasInput := false
 	for _, input := range e.wasmArguments {
@@ -173,6 +196,8 @@ func (e *BaseExecutor) wasmCall(reader execout.ExecutionOutputGetter) (instance
 		case *wasm.StoreWriterOutput:
 		case *wasm.StoreReaderInput:
 			hasInput = true
+		case *wasm.ParamsInput:
+			// value is set once, when the executor is built
 		case wasm.ValueArgument:
 			hasInput = true
 			data, _, err := reader.Get(v.Name())
@@ -195,12 +220,31 @@ func (e *BaseExecutor) wasmCall(reader execout.ExecutionOutputGetter) (instance
 			return nil, fmt.Errorf("new wasm instance: %w", err)
 		}
 
-		if err = instance.Execute(); err != nil {
-			errExecutor := ErrorExecutor{
+		if limit, limited := e.fuelBudget.limit(); limited {
+			instance.SetFuelLimit(limit)
+		}
+
+		err = instance.Execute()
+		e.fuelBudget.consumed(instance.FuelConsumed)
+		moduleLabel := metrics.ModuleLabel(e.moduleName)
+		metrics.WASMMemorySize.SetUint64(e.wasmModule.MemorySize(), moduleLabel)
+		metrics.WASMMemoryPeakSize.SetUint64(e.wasmModule.PeakMemorySize(), moduleLabel)
+		if err != nil {
+			var outOfFuel *wasm.OutOfFuelError
+			if errors.As(err, &outOfFuel) {
+				return nil, fmt.Errorf("block %d: module %q: %w", clock.Number, e.moduleName, outOfFuel)
+			}
+			var memoryLimit *wasm.MemoryLimitError
+			if errors.As(err, &memoryLimit) {
+				return nil, fmt.Errorf("block %d: module %q: %w", clock.Number, e.moduleName, memoryLimit)
+			}
+
+			errExecutor := &ErrorExecutor{
 				message:    err.Error(),
 				stackTrace: instance.ExecutionStack,
+				cause:      err,
 			}
-			return nil, fmt.Errorf("block %d: module %q: wasm execution failed: %v", clock.Number, e.moduleName, errExecutor.Error())
+			return nil, fmt.Errorf("block %d: module %q: wasm execution failed: %w", clock.Number, e.moduleName, errExecutor)
 		}
 		err = instance.Module.Heap.Clear()
 		if err != nil {
@@ -0,0 +1,149 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/streamingfast/substreams/native"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/streamingfast/substreams/pipeline/execout"
+	"github.com/streamingfast/substreams/store"
+	"github.com/streamingfast/substreams/wasm"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	ttrace "go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+var _ ModuleExecutor = (*NativeModuleExecutor)(nil)
+
+// NativeModuleExecutor runs a module whose handler is a Go function of the
+// `native.Registry`. Its arguments are the ones built for WASM modules, so
+// inputs are resolved, and outputs cached, the same way.
+type NativeModuleExecutor struct {
+	moduleName string
+	arguments  []wasm.Argument
+	tracer     ttrace.Tracer
+
+	mapHandler native.MapHandler
+	outputType string
+
+	storeHandler native.StoreHandler
+	outputStore  store.Store
+}
+
+func (e *NativeModuleExecutor) Name() string { return e.moduleName }
+
+func (e *NativeModuleExecutor) String() string { return e.Name() }
+
+func (e *NativeModuleExecutor) Reset() {}
+
+func (e *NativeModuleExecutor) applyCachedOutput(value []byte) error {
+	if e.outputStore == nil {
+		return nil
+	}
+	deltas := &pbsubstreams.StoreDeltas{}
+	if err := proto.Unmarshal(value, deltas); err != nil {
+		return fmt.Errorf("unmarshalling output deltas: %w", err)
+	}
+	e.outputStore.SetDeltas(deltas.Deltas)
+	return nil
+}
+
+func (e *NativeModuleExecutor) moduleLogs() (logs []string, structuredLogs []*pbsubstreams.ModuleLog, truncated bool) {
+	return nil, nil, false
+}
+
+func (e *NativeModuleExecutor) currentExecutionStack() []string { return nil }
+
+func (e *NativeModuleExecutor) run(ctx context.Context, reader execout.ExecutionOutputGetter) (out []byte, moduleOutput pbsubstreams.ModuleOutputData, err error) {
+	ctx, span := e.tracer.Start(ctx, "exec_native")
+	span.SetAttributes(attribute.String("module", e.moduleName))
+	defer span.End()
+
+	clock := reader.Clock()
+	inputs, hasInput, err := e.inputs(reader)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, nil, err
+	}
+
+	if e.outputStore != nil {
+		// like WASM store modules, not called when there is nothing to read
+		if hasInput {
+			if err := e.call(clock, func() error { return e.storeHandler(ctx, clock, inputs, e.outputStore) }); err != nil {
+				span.SetStatus(codes.Error, err.Error())
+				return nil, nil, err
+			}
+		}
+		if err := e.outputStore.Err(); err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			return nil, nil, fmt.Errorf("writing store: %w", err)
+		}
+
+		deltas := &pbsubstreams.StoreDeltas{
+			Deltas: e.outputStore.GetDeltas(),
+		}
+		data, err := proto.Marshal(deltas)
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			return nil, nil, fmt.Errorf("caching: marshalling delta: %w", err)
+		}
+
+		span.SetStatus(codes.Ok, "module_executed")
+		return data, &pbsubstreams.ModuleOutput_StoreDeltas{StoreDeltas: deltas}, nil
+	}
+
+	if hasInput {
+		if err := e.call(clock, func() (err error) { out, err = e.mapHandler(ctx, clock, inputs); return }); err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			return nil, nil, err
+		}
+	}
+	if out != nil {
+		moduleOutput = &pbsubstreams.ModuleOutput_MapOutput{
+			MapOutput: &anypb.Any{TypeUrl: "type.googleapis.com/" + e.outputType, Value: out},
+		}
+	}
+
+	span.SetStatus(codes.Ok, "module_executed")
+	return out, moduleOutput, nil
+}
+
+func (e *NativeModuleExecutor) inputs(reader execout.ExecutionOutputGetter) (inputs []*native.Input, hasInput bool, err error) {
+	for _, argument := range e.arguments {
+		switch v := argument.(type) {
+		case *wasm.StoreReaderInput:
+			hasInput = true
+			inputs = append(inputs, &native.Input{Name: v.Name(), Store: v.Store})
+		case *wasm.ParamsInput:
+			inputs = append(inputs, &native.Input{Name: v.Name(), Value: v.Value()})
+		case wasm.ValueArgument:
+			hasInput = true
+			data, _, err := reader.Get(v.Name())
+			if err != nil {
+				return nil, false, fmt.Errorf("failed to get block input data: %w", err)
+			}
+			inputs = append(inputs, &native.Input{Name: v.Name(), Value: data})
+		default:
+			panic("unknown argument type")
+		}
+	}
+	return inputs, hasInput, nil
+}
+
+// call turns a handler's error or panic into the failure of the module,
+// a panicking handler must not bring the whole process down.
+func (e *NativeModuleExecutor) call(clock *pbsubstreams.Clock, f func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("block %d: module %q: native handler panicked: %v", clock.Number, e.moduleName, r)
+		}
+	}()
+
+	if err := f(); err != nil {
+		return fmt.Errorf("block %d: module %q: native execution failed: %w", clock.Number, e.moduleName, err)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package pipeline
+
+// fuelBudget tracks the fuel WASM modules may consume. `perModule` bounds a
+// single module execution, `perBlock` bounds all executions of a block
+// together. A zero value means unlimited.
+type fuelBudget struct {
+	perModule uint64
+	perBlock  uint64
+
+	blockConsumed uint64
+}
+
+func newFuelBudget(perModule, perBlock uint64) *fuelBudget {
+	if perModule == 0 && perBlock == 0 {
+		return nil
+	}
+	return &fuelBudget{
+		perModule: perModule,
+		perBlock:  perBlock,
+	}
+}
+
+func (b *fuelBudget) newBlock() {
+	if b == nil {
+		return
+	}
+	b.blockConsumed = 0
+}
+
+// limit returns the fuel available to the next module execution.
+func (b *fuelBudget) limit() (limit uint64, limited bool) {
+	if b == nil {
+		return 0, false
+	}
+
+	if b.perBlock != 0 {
+		if b.blockConsumed < b.perBlock {
+			limit = b.perBlock - b.blockConsumed
+		}
+		if b.perModule != 0 && b.perModule < limit {
+			limit = b.perModule
+		}
+		return limit, true
+	}
+	return b.perModule, true
+}
+
+func (b *fuelBudget) consumed(fuel uint64) {
+	if b == nil {
+		return
+	}
+	b.blockConsumed += fuel
+}
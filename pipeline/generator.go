@@ -3,6 +3,7 @@ package pipeline
 import (
 	"fmt"
 	"github.com/streamingfast/dstore"
+	"github.com/streamingfast/substreams/compress"
 	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
 	"github.com/streamingfast/substreams/store"
 	"go.uber.org/zap"
@@ -11,17 +12,47 @@ import (
 type StoreFactory struct {
 	baseStore    dstore.Store
 	saveInterval uint64
+
+	skipValueValidation bool
+	compression         compress.Compression
+	disk                *store.DiskConfig
+	checkpointInterval  uint64
 }
 
 func NewStoreFactory(baseStore dstore.Store, saveInterval uint64) *StoreFactory {
 	return &StoreFactory{
 		baseStore:    baseStore,
 		saveInterval: saveInterval,
+		compression:  compress.Default,
 	}
 }
 
+// DisableValueValidation turns off the value validation of the stores created
+// afterwards, see `store.BaseStore.DisableValueValidation`.
+func (g *StoreFactory) DisableValueValidation() {
+	g.skipValueValidation = true
+}
+
+// SetCompression sets the compression of the snapshots saved by the stores
+// created afterwards.
+func (g *StoreFactory) SetCompression(compression compress.Compression) {
+	g.compression = compression
+}
+
+// SetDiskConfig holds the stores created afterwards on disk as selected by
+// `config`, see `store.DiskConfig`.
+func (g *StoreFactory) SetDiskConfig(config *store.DiskConfig) {
+	g.disk = config
+}
+
+// SetCheckpointInterval sets the number of snapshots per full checkpoint of
+// the full stores created afterwards, see `store.BaseStore.SetCheckpointInterval`.
+func (g *StoreFactory) SetCheckpointInterval(interval uint64) {
+	g.checkpointInterval = interval
+}
+
 func (g *StoreFactory) NewFullKV(hash string, storeModule *pbsubstreams.Module, logger *zap.Logger) (*store.FullKV, error) {
-	return store.NewFullKV(
+	s, err := store.NewFullKV(
 		storeModule.Name,
 		storeModule.InitialBlock,
 		hash,
@@ -30,6 +61,20 @@ func (g *StoreFactory) NewFullKV(hash string, storeModule *pbsubstreams.Module,
 		g.baseStore,
 		logger,
 	)
+	if err != nil {
+		return nil, err
+	}
+	if g.skipValueValidation {
+		s.DisableValueValidation()
+	}
+	s.SetCompression(g.compression)
+	s.SetCheckpointInterval(g.checkpointInterval)
+	if g.disk != nil {
+		if err := s.SetDiskConfig(g.disk); err != nil {
+			return nil, fmt.Errorf("store %q on disk: %w", storeModule.Name, err)
+		}
+	}
+	return s, nil
 }
 
 func (g *StoreFactory) NewPartialKV(hash string, storeModule *pbsubstreams.Module, initialBlock uint64, logger *zap.Logger) (*store.PartialKV, error) {
@@ -45,5 +90,15 @@ func (g *StoreFactory) NewPartialKV(hash string, storeModule *pbsubstreams.Modul
 	if err != nil {
 		return nil, fmt.Errorf("failed to create store: %w", err)
 	}
+	if g.skipValueValidation {
+		s.DisableValueValidation()
+	}
+	s.SetCompression(g.compression)
+	s.SetCheckpointInterval(g.checkpointInterval)
+	if g.disk != nil {
+		if err := s.SetDiskConfig(g.disk); err != nil {
+			return nil, fmt.Errorf("store %q on disk: %w", storeModule.Name, err)
+		}
+	}
 	return store.NewPartialKV(s, initialBlock), nil
 }
@@ -0,0 +1,267 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/streamingfast/dstore"
+	"github.com/streamingfast/substreams/block"
+	"github.com/streamingfast/substreams/index"
+	"github.com/streamingfast/substreams/manifest"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+// blockIndexes tracks the keys emitted by index modules, so the modules
+// filtered on them are skipped on the blocks not matching their query.
+//
+// Keys are saved in index files ranged like the store snapshots. When the
+// file of the current range exists, keys are read from it and the index
+// module is not executed at all.
+type blockIndexes struct {
+	baseStore    dstore.Store
+	saveInterval uint64
+	logger       *zap.Logger
+
+	indexes map[string]*moduleIndex // by index module name
+	filters map[string]*blockFilter // by filtered module name
+
+	irreversible bool // the current block is
+}
+
+type moduleIndex struct {
+	name         string
+	store        dstore.Store // nil when index files are disabled
+	initialBlock uint64
+
+	file     *index.File // of the current range
+	loaded   bool        // file was read from the store
+	complete bool        // all the blocks of the range were, or are being, processed, irreversible
+
+	keys map[string]bool // of the current block, when not loaded
+}
+
+type blockFilter struct {
+	index *moduleIndex
+	query index.Query
+}
+
+func newBlockIndexes(baseStore dstore.Store, saveInterval uint64, logger *zap.Logger) *blockIndexes {
+	return &blockIndexes{
+		baseStore:    baseStore,
+		saveInterval: saveInterval,
+		logger:       logger,
+		indexes:      map[string]*moduleIndex{},
+		filters:      map[string]*blockFilter{},
+	}
+}
+
+func (b *blockIndexes) init(modules []*pbsubstreams.Module, hashes *manifest.ModuleHashes) error {
+	if b == nil {
+		return nil
+	}
+
+	for _, module := range modules {
+		if module.GetKindIndex() == nil {
+			continue
+		}
+		idx := &moduleIndex{name: module.Name, initialBlock: module.InitialBlock}
+		if b.baseStore != nil && b.saveInterval != 0 {
+			store, err := b.baseStore.SubStore(fmt.Sprintf("%s/index", hashes.Get(module.Name)))
+			if err != nil {
+				return fmt.Errorf("creating index store for module %q: %w", module.Name, err)
+			}
+			idx.store = store
+		}
+		b.indexes[module.Name] = idx
+	}
+
+	for _, module := range modules {
+		if module.BlockFilter == nil {
+			continue
+		}
+		idx, found := b.indexes[module.BlockFilter.Module]
+		if !found {
+			return fmt.Errorf("module %q: index module %q not found", module.Name, module.BlockFilter.Module)
+		}
+		query, err := index.ParseQuery(module.BlockFilter.Query)
+		if err != nil {
+			return fmt.Errorf("module %q: %w", module.Name, err)
+		}
+		b.filters[module.Name] = &blockFilter{index: idx, query: query}
+	}
+	return nil
+}
+
+// newBlock saves the index files of the ranges ending before `blockNum`, and
+// loads the ones of the ranges it starts. Only the keys of irreversible
+// blocks are added to the files, a range having a reversible block is not
+// saved.
+func (b *blockIndexes) newBlock(ctx context.Context, blockNum uint64, irreversible bool) error {
+	if b == nil {
+		return nil
+	}
+
+	b.irreversible = irreversible
+	for _, idx := range b.indexes {
+		idx.keys = nil
+		if idx.store == nil || blockNum < idx.initialBlock {
+			continue
+		}
+		if idx.file == nil || blockNum >= idx.file.Range.ExclusiveEndBlock {
+			if err := b.roll(ctx, idx, blockNum); err != nil {
+				return err
+			}
+		}
+		if !irreversible {
+			idx.complete = false
+		}
+	}
+	return nil
+}
+
+func (b *blockIndexes) roll(ctx context.Context, idx *moduleIndex, blockNum uint64) error {
+	rolled := false
+	if idx.file != nil {
+		if err := b.save(ctx, idx); err != nil {
+			return err
+		}
+		rolled = idx.file.Range.ExclusiveEndBlock == b.rangeStart(idx, blockNum)
+	}
+
+	r := b.indexRange(idx, blockNum)
+	file, err := index.LoadFile(ctx, idx.store, r)
+	if err != nil {
+		return fmt.Errorf("loading index of module %q: %w", idx.name, err)
+	}
+
+	idx.loaded = file != nil
+	idx.complete = rolled || blockNum == r.StartBlock
+	idx.file = file
+	if file == nil {
+		idx.file = index.NewFile(r)
+	}
+	return nil
+}
+
+// flush saves the index files of the ranges ending at or before
+// `stopBlock`, the last range of a request stopping on a boundary being
+// otherwise only saved when a block past it is processed.
+func (b *blockIndexes) flush(ctx context.Context, stopBlock uint64) error {
+	if b == nil {
+		return nil
+	}
+	for _, idx := range b.indexes {
+		if idx.file == nil || idx.file.Range.ExclusiveEndBlock > stopBlock {
+			continue
+		}
+		if err := b.save(ctx, idx); err != nil {
+			return err
+		}
+		idx.complete = false // saved, nothing more to write
+	}
+	return nil
+}
+
+func (b *blockIndexes) save(ctx context.Context, idx *moduleIndex) error {
+	if !idx.complete || idx.loaded {
+		return nil
+	}
+	if err := idx.file.Save(ctx, idx.store); err != nil {
+		return fmt.Errorf("saving index of module %q: %w", idx.name, err)
+	}
+	b.logger.Debug("index file written", zap.String("module", idx.name), zap.Stringer("range", idx.file.Range))
+	return nil
+}
+
+// indexRange returns the range of the index file holding `blockNum`.
+func (b *blockIndexes) indexRange(idx *moduleIndex, blockNum uint64) *block.Range {
+	return block.NewRange(b.rangeStart(idx, blockNum), blockNum-blockNum%b.saveInterval+b.saveInterval)
+}
+
+func (b *blockIndexes) rangeStart(idx *moduleIndex, blockNum uint64) uint64 {
+	start := blockNum - blockNum%b.saveInterval
+	if start < idx.initialBlock {
+		return idx.initialBlock
+	}
+	return start
+}
+
+// keysLoaded tells if `moduleName` is an index module whose keys for the
+// current block were read from its index file, it then needs not run.
+func (b *blockIndexes) keysLoaded(moduleName string) bool {
+	if b == nil {
+		return false
+	}
+	idx, found := b.indexes[moduleName]
+	return found && idx.loaded
+}
+
+// matches tells if the current block matches the filter of `moduleName`,
+// always true for unfiltered modules.
+func (b *blockIndexes) matches(moduleName string, blockNum uint64) bool {
+	if b == nil {
+		return true
+	}
+	filter, found := b.filters[moduleName]
+	if !found {
+		return true
+	}
+
+	keys := filter.index.keys
+	if filter.index.loaded {
+		keys = filter.index.file.Keys(blockNum)
+	}
+	return filter.query.Matches(keys)
+}
+
+// record sets the keys of the current block from the output of the index
+// module `moduleName`, it is a no-op for other modules.
+func (b *blockIndexes) record(moduleName string, blockNum uint64, output []byte) error {
+	if b == nil {
+		return nil
+	}
+	idx, found := b.indexes[moduleName]
+	if !found {
+		return nil
+	}
+
+	keys := &pbsubstreams.IndexKeys{}
+	if err := proto.Unmarshal(output, keys); err != nil {
+		return fmt.Errorf("unmarshalling index keys: %w", err)
+	}
+
+	idx.keys = make(map[string]bool, len(keys.Keys))
+	for _, key := range keys.Keys {
+		idx.keys[key] = true
+	}
+	if idx.file != nil && b.irreversible {
+		idx.file.Add(blockNum, keys.Keys)
+	}
+	return nil
+}
+
+// unmatchedRange tells if no block of range `r` matches the filter of
+// `moduleName`, according to the index files previously written. The
+// module's partial store for `r` is then known to be empty. Only a range
+// matching exactly the one of an index file can be told.
+func (b *blockIndexes) unmatchedRange(ctx context.Context, moduleName string, r *block.Range) (bool, error) {
+	if b == nil {
+		return false, nil
+	}
+	filter, found := b.filters[moduleName]
+	if !found || filter.index.store == nil {
+		return false, nil
+	}
+	if indexRange := b.indexRange(filter.index, r.StartBlock); !indexRange.Equals(r) {
+		b.logger.Debug("range not aligned on index files", zap.String("module", moduleName), zap.Stringer("range", r), zap.Stringer("index_range", indexRange))
+		return false, nil
+	}
+
+	file, err := index.LoadFile(ctx, filter.index.store, r)
+	if err != nil {
+		return false, fmt.Errorf("loading index of module %q: %w", filter.index.name, err)
+	}
+	return file != nil && !file.Matches(filter.query), nil
+}
@@ -0,0 +1,117 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/streamingfast/bstream"
+	"github.com/streamingfast/dstore"
+	"github.com/streamingfast/substreams/block"
+	"github.com/streamingfast/substreams/index"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/streamingfast/substreams/pipeline/execout"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+// newTestBlockIndexes indexes the module `index` in 10 blocks files, the
+// module `filtered` being filtered on the key `transfer`.
+func newTestBlockIndexes(t *testing.T) (*blockIndexes, dstore.Store) {
+	t.Helper()
+	store, err := dstore.NewStore("file://"+t.TempDir(), "", "", false)
+	require.NoError(t, err)
+
+	query, err := index.ParseQuery("transfer")
+	require.NoError(t, err)
+
+	b := newBlockIndexes(nil, 10, zap.NewNop())
+	idx := &moduleIndex{name: "index", store: store}
+	b.indexes["index"] = idx
+	b.filters["filtered"] = &blockFilter{index: idx, query: query}
+	return b, store
+}
+
+// processIndex processes the blocks `from` to `to` excluded, the ones in
+// `reversible` not being irreversible, the index emitting `keys` on each.
+func processIndex(t *testing.T, b *blockIndexes, from, to uint64, keys []string, reversible ...uint64) {
+	t.Helper()
+	output, err := proto.Marshal(&pbsubstreams.IndexKeys{Keys: keys})
+	require.NoError(t, err)
+
+	for num := from; num < to; num++ {
+		irreversible := true
+		for _, r := range reversible {
+			irreversible = irreversible && r != num
+		}
+		require.NoError(t, b.newBlock(context.Background(), num, irreversible))
+		require.NoError(t, b.record("index", num, output))
+	}
+}
+
+func TestBlockIndexes_reversibleBlocks(t *testing.T) {
+	ctx := context.Background()
+	b, store := newTestBlockIndexes(t)
+
+	processIndex(t, b, 0, 10, nil, 5)
+	processIndex(t, b, 10, 20, nil)
+	require.NoError(t, b.newBlock(ctx, 20, true))
+
+	exists, err := store.FileExists(ctx, index.Filename(block.NewRange(0, 10)))
+	require.NoError(t, err)
+	assert.False(t, exists, "range with a reversible block")
+	exists, err = store.FileExists(ctx, index.Filename(block.NewRange(10, 20)))
+	require.NoError(t, err)
+	assert.True(t, exists)
+}
+
+func TestBlockIndexes_flush(t *testing.T) {
+	ctx := context.Background()
+	b, _ := newTestBlockIndexes(t)
+
+	processIndex(t, b, 10, 20, []string{"mint"})
+	unmatched, err := b.unmatchedRange(ctx, "filtered", block.NewRange(10, 20))
+	require.NoError(t, err)
+	assert.False(t, unmatched, "not saved before the stop block")
+
+	require.NoError(t, b.flush(ctx, 20))
+	unmatched, err = b.unmatchedRange(ctx, "filtered", block.NewRange(10, 20))
+	require.NoError(t, err)
+	assert.True(t, unmatched)
+
+	unmatched, err = b.unmatchedRange(ctx, "filtered", block.NewRange(10, 15))
+	require.NoError(t, err)
+	assert.False(t, unmatched, "range not aligned on the index files")
+}
+
+type countingPayload struct {
+	data []byte
+	gets int
+}
+
+func (p *countingPayload) Get() ([]byte, error) {
+	p.gets++
+	return p.data, nil
+}
+
+func TestPipeline_runExecutor_filteredBlockNotDecoded(t *testing.T) {
+	b, _ := newTestBlockIndexes(t)
+	b.filters["failing"] = b.filters["filtered"]
+	require.NoError(t, b.newBlock(context.Background(), 10, true))
+
+	payload := &countingPayload{data: []byte("block")}
+	blk := &bstream.Block{Id: "block-10", Number: 10, Payload: payload}
+	execOutput, err := execout.NewExecOutputMap("sf.substreams.v1.test.Block", blk, &pbsubstreams.Clock{Id: blk.Id, Number: blk.Number})
+	require.NoError(t, err)
+
+	pipe := &Pipeline{reqCtx: testRequestContext(context.Background()), blockIndexes: b}
+	require.NoError(t, pipe.runExecutor(&failingExecutor{err: fmt.Errorf("executed")}, execOutput))
+	assert.Equal(t, 0, payload.gets)
+
+	data, _, err := execOutput.Get("sf.substreams.v1.test.Block")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("block"), data)
+	assert.Equal(t, 1, payload.gets)
+}
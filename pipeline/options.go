@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/streamingfast/substreams"
+	"github.com/streamingfast/substreams/native"
 	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
 )
 
@@ -36,3 +37,20 @@ func WithSyncBlockRangeRestriction(maxRangeSize uint64) Option {
 		p.maxStoreSyncRangeSize = maxRangeSize
 	}
 }
+
+// WithFuelBudget bounds the fuel WASM modules may consume, `perModule` for a
+// single module execution and `perBlock` for all executions of a block. Zero
+// means unlimited. The `wasm.Runtime` must have fuel metering enabled.
+func WithFuelBudget(perModule, perBlock uint64) Option {
+	return func(p *Pipeline) {
+		p.fuelBudget = newFuelBudget(perModule, perBlock)
+	}
+}
+
+// WithNativeRegistry provides the Go handlers of modules using a
+// `native/go-v1` binary.
+func WithNativeRegistry(registry *native.Registry) Option {
+	return func(p *Pipeline) {
+		p.nativeRegistry = registry
+	}
+}
@@ -1,17 +1,23 @@
 package pipeline
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"math"
 	"strings"
+	"time"
 
 	"github.com/streamingfast/substreams/pipeline/execout"
 
+	"github.com/bytecodealliance/wasmtime-go"
 	"github.com/streamingfast/bstream"
 	"github.com/streamingfast/dstore"
 	"github.com/streamingfast/substreams"
 	"github.com/streamingfast/substreams/block"
 	"github.com/streamingfast/substreams/manifest"
+	"github.com/streamingfast/substreams/metrics"
+	"github.com/streamingfast/substreams/native"
 	"github.com/streamingfast/substreams/orchestrator"
 	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
 	"github.com/streamingfast/substreams/store"
@@ -23,7 +29,7 @@ import (
 )
 
 type Pipeline struct {
-	vmType    string // wasm/rust-v1, native
+	vmType    string // wasm/rust-v1, wasm/wasi-v1
 	blockType string
 
 	maxStoreSyncRangeSize uint64
@@ -33,7 +39,10 @@ type Pipeline struct {
 	postJobHooks   []substreams.PostJobHook
 
 	wasmRuntime    *wasm.Runtime
-	wasmExtensions []wasm.WASMExtensioner
+	fuelBudget     *fuelBudget
+	nativeRegistry *native.Registry
+	timings        *moduleTimings
+	blockIndexes   *blockIndexes
 
 	reqCtx *RequestContext
 
@@ -63,7 +72,7 @@ type Pipeline struct {
 	bounder      *StoreBoundary
 }
 
-func New(reqCtx *RequestContext, graph *manifest.ModuleGraph, blockType string, wasmExtensions []wasm.WASMExtensioner, subRequestSplitSize int, engine execout.CacheEngine, storeMap *store.Map, storeGenerator *StoreFactory, bounder *StoreBoundary, respFunc func(resp *pbsubstreams.Response) error, opts ...Option) *Pipeline {
+func New(reqCtx *RequestContext, graph *manifest.ModuleGraph, blockType string, wasmRuntime *wasm.Runtime, subRequestSplitSize int, engine execout.CacheEngine, storeMap *store.Map, storeGenerator *StoreFactory, bounder *StoreBoundary, respFunc func(resp *pbsubstreams.Response) error, opts ...Option) *Pipeline {
 	pipe := &Pipeline{
 		reqCtx:        reqCtx,
 		cachingEngine: engine,
@@ -75,12 +84,14 @@ func New(reqCtx *RequestContext, graph *manifest.ModuleGraph, blockType string,
 		graph:                 graph,
 		outputModuleMap:       map[string]bool{},
 		blockType:             blockType,
-		wasmExtensions:        wasmExtensions,
+		wasmRuntime:           wasmRuntime,
 		subrequestSplitSize:   subRequestSplitSize,
 		maxStoreSyncRangeSize: math.MaxUint64,
 		respFunc:              respFunc,
 		bounder:               bounder,
 		forkHandler:           NewForkHandle(),
+		timings:               newModuleTimings(timingsReportInterval),
+		blockIndexes:          newBlockIndexes(storeGenerator.baseStore, storeGenerator.saveInterval, reqCtx.logger),
 	}
 
 	for _, name := range reqCtx.Request().OutputModules {
@@ -119,6 +130,10 @@ func (p *Pipeline) Init(workerPool *orchestrator.WorkerPool) (err error) {
 		return fmt.Errorf("module failed validation: %w", err)
 	}
 
+	if err := p.blockIndexes.init(modules, p.moduleHashes); err != nil {
+		return fmt.Errorf("failed to setup block indexes: %w", err)
+	}
+
 	p.reqCtx.logger.Info("priming caching engine")
 	if err := p.cachingEngine.Init(p.moduleHashes); err != nil {
 		return fmt.Errorf("failed to prime caching engine: %w", err)
@@ -198,20 +213,39 @@ func (p *Pipeline) setupSubrequestStores(storeModules []*pbsubstreams.Module) er
 		}
 
 		if err := store.Load(p.reqCtx, p.reqCtx.StartBlockNum()); err != nil {
-			return fmt.Errorf("failed to initialize store: %w", err)
+			return fmt.Errorf("failed to initialize store: %w", p.deleteCorruptedSnapshot(store, err))
 		}
 	}
 	p.backprocessingStores = append(p.backprocessingStores, partialStore)
 	return nil
 }
 
+// deleteCorruptedSnapshot deletes the snapshot that `err` failed to load when
+// it is corrupted, returning `err`. The sub-request then fails without retry,
+// and the parent plans the work again with the snapshot missing.
+func (p *Pipeline) deleteCorruptedSnapshot(s store.Store, err error) error {
+	if !errors.Is(err, store.ErrCorruptedSnapshot) {
+		return err
+	}
+	deleter, ok := s.(interface {
+		DeleteCorruptedSnapshot(ctx context.Context, err error) error
+	})
+	if !ok {
+		return err
+	}
+	if deleteErr := deleter.DeleteCorruptedSnapshot(p.reqCtx, err); deleteErr != nil {
+		return fmt.Errorf("%s: %w", err, deleteErr)
+	}
+	return err
+}
+
 func (p *Pipeline) runBackProcessAndSetupStores(workerPool *orchestrator.WorkerPool, storeModules []*pbsubstreams.Module) error {
 	// this is a long run process, it will run the whole back process logic
 	backProcessedStores, err := p.backProcessStores(workerPool, storeModules)
 	if err != nil {
 		return fmt.Errorf("synchronizing stores: %w", err)
 	}
-	
+
 	for modName, store := range backProcessedStores {
 		p.storeMap.Set(modName, store)
 	}
@@ -319,45 +353,82 @@ func (p *Pipeline) runExecutor(executor ModuleExecutor, execOutput execout.Execu
 	// }
 
 	executorName := executor.Name()
+	blockNum := execOutput.Clock().Number
+	if p.blockIndexes.keysLoaded(executorName) && !p.isOutputModule(executorName) {
+		p.reqCtx.logger.Debug("index keys loaded, skipping", zap.String("module_name", executorName))
+		return nil
+	}
+	if !p.blockIndexes.matches(executorName, blockNum) {
+		p.reqCtx.logger.Debug("block filtered out, skipping", zap.String("module_name", executorName))
+		// the module produced nothing on this block
+		if err := execOutput.Set(executorName, nil); err != nil {
+			return fmt.Errorf("failed to set output %w", err)
+		}
+		return nil
+	}
+
 	p.reqCtx.logger.Debug("executing", zap.String("module_name", executorName))
 
+	start := time.Now()
 	output, cached, err := execOutput.Get(executor.Name())
 	if err != nil && err != execout.NotFound {
 		return fmt.Errorf("error getting module %q output: %w", executor.Name(), err)
 	}
+	defer func() {
+		elapsed := time.Since(start)
+		p.timings.observe(executorName, cached, elapsed)
+		if cached {
+			metrics.ModuleCacheHitDuration.ObserveDuration(elapsed, metrics.ModuleLabel(executorName))
+		} else {
+			metrics.ModuleExecutionDuration.ObserveDuration(elapsed, metrics.ModuleLabel(executorName))
+		}
+	}()
 	if cached {
 		if err := executor.applyCachedOutput(output); err != nil {
 			return fmt.Errorf("failed to apply cache output for module %q: %w", executorName, err)
 		}
+		if err := p.blockIndexes.record(executorName, blockNum, output); err != nil {
+			return fmt.Errorf("module %q: %w", executorName, err)
+		}
 		return nil
 	}
 
 	outputData, moduleOutputData, err := executor.run(p.reqCtx, execOutput)
 	if err != nil {
-		logs, truncated := executor.moduleLogs()
+		logs, structuredLogs, truncated := executor.moduleLogs()
 		if len(logs) != 0 || moduleOutputData != nil {
 			p.moduleOutputs = append(p.moduleOutputs, &pbsubstreams.ModuleOutput{
-				Name:          executorName,
-				Data:          moduleOutputData,
-				Logs:          logs,
-				LogsTruncated: truncated,
+				Name:           executorName,
+				Data:           moduleOutputData,
+				Logs:           logs,
+				LogsTruncated:  truncated,
+				StructuredLogs: structuredLogs,
 			})
 		}
+		if isDeterministicFailure(err) {
+			if progressErr := p.returnFailureProgress(err, executor); progressErr != nil {
+				p.reqCtx.logger.Warn("unable to return failure progress", zap.String("module_name", executorName), zap.Error(progressErr))
+			}
+		}
 		return fmt.Errorf("running module: %w", err)
 	}
 
 	if err := execOutput.Set(executor.Name(), outputData); err != nil {
 		return fmt.Errorf("failed to set output %w", err)
 	}
+	if err := p.blockIndexes.record(executorName, blockNum, outputData); err != nil {
+		return fmt.Errorf("module %q: %w", executorName, err)
+	}
 
 	if p.isOutputModule(executorName) {
-		logs, truncated := executor.moduleLogs()
+		logs, structuredLogs, truncated := executor.moduleLogs()
 		if len(logs) != 0 || moduleOutputData != nil {
 			moduleOutput := &pbsubstreams.ModuleOutput{
-				Name:          executorName,
-				Data:          moduleOutputData,
-				Logs:          logs,
-				LogsTruncated: truncated,
+				Name:           executorName,
+				Data:           moduleOutputData,
+				Logs:           logs,
+				LogsTruncated:  truncated,
+				StructuredLogs: structuredLogs,
 			}
 			p.moduleOutputs = append(p.moduleOutputs, moduleOutput)
 			p.forkHandler.addReversibleOutput(moduleOutput, execOutput.Clock().Number)
@@ -409,6 +480,35 @@ func (p *Pipeline) returnModuleProgressOutputs(clock *pbsubstreams.Clock) error
 	return nil
 }
 
+// returnModuleTimings sends the timings accumulated since the last call, at
+// most once per `timingsReportInterval` unless `force` is set.
+func (p *Pipeline) returnModuleTimings(force bool) error {
+	progress := p.timings.progress(time.Now(), force)
+	if len(progress) == 0 {
+		return nil
+	}
+
+	if err := p.respFunc(substreams.NewModulesProgressResponse(progress)); err != nil {
+		return fmt.Errorf("calling return func: %w", err)
+	}
+	return nil
+}
+
+// isDeterministicFailure tells if `err` fails the module the same way on any
+// server, like exhausting its fuel or memory limits, panicking or trapping.
+// Failed progress makes the sub-request fail without retry, so it is only sent
+// for those: other errors may be transient, and the sub-request is retried.
+func isDeterministicFailure(err error) bool {
+	var outOfFuel *wasm.OutOfFuelError
+	var memoryLimit *wasm.MemoryLimitError
+	var panicErr *wasm.PanicError
+	var trap *wasmtime.Trap
+	return errors.As(err, &outOfFuel) ||
+		errors.As(err, &memoryLimit) ||
+		errors.As(err, &panicErr) ||
+		errors.As(err, &trap)
+}
+
 func (p *Pipeline) returnFailureProgress(err error, failedExecutor ModuleExecutor) error {
 	var out []*pbsubstreams.ModuleProgress
 
@@ -448,8 +548,14 @@ func (p *Pipeline) returnFailureProgress(err error, failedExecutor ModuleExecuto
 
 func (p *Pipeline) validateBinaries() error {
 	for _, binary := range p.reqCtx.Request().Modules.Binaries {
-		if binary.Type != "wasm/rust-v1" {
-			return fmt.Errorf("unsupported binary type: %q, supported: %q", binary.Type, p.vmType)
+		switch binary.Type {
+		case wasm.BinaryTypeRustV1, wasm.BinaryTypeWASIV1:
+		case native.BinaryTypeGoV1:
+			if err := p.nativeRegistry.CheckBinary(string(binary.Content)); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unsupported binary type: %q, supported: %q, %q, %q", binary.Type, wasm.BinaryTypeRustV1, wasm.BinaryTypeWASIV1, native.BinaryTypeGoV1)
 		}
 		p.vmType = binary.Type
 	}
@@ -467,7 +573,6 @@ func (p *Pipeline) getModules() (modules []*pbsubstreams.Module, storeModules []
 }
 
 func (p *Pipeline) buildWASM(modules []*pbsubstreams.Module) error {
-	p.wasmRuntime = wasm.NewRuntime(p.wasmExtensions)
 	tracer := otel.GetTracerProvider().Tracer("executor")
 
 	for _, module := range modules {
@@ -479,7 +584,20 @@ func (p *Pipeline) buildWASM(modules []*pbsubstreams.Module) error {
 		modName := module.Name // to ensure it's enclosed
 		entrypoint := module.BinaryEntrypoint
 		code := p.reqCtx.Request().Modules.Binaries[module.BinaryIndex]
-		wasmModule, err := p.wasmRuntime.NewModule(p.reqCtx, p.reqCtx.Request(), code.Content, module.Name, entrypoint)
+		if code.Type == native.BinaryTypeGoV1 {
+			binaryName, _, err := native.ParseBinaryContent(string(code.Content))
+			if err != nil {
+				return fmt.Errorf("module %q: %w", module.Name, err)
+			}
+			executor, err := p.newNativeExecutor(module, binaryName, inputs, tracer)
+			if err != nil {
+				return fmt.Errorf("module %q: %w", module.Name, err)
+			}
+			p.moduleExecutors = append(p.moduleExecutors, executor)
+			continue
+		}
+
+		wasmModule, err := p.wasmRuntime.NewModule(p.reqCtx, p.reqCtx.Request(), code.Type, code.Content, module.Name, p.moduleHashes.Get(module.Name), entrypoint)
 		if err != nil {
 			return fmt.Errorf("new wasm module: %w", err)
 		}
@@ -494,6 +612,7 @@ func (p *Pipeline) buildWASM(modules []*pbsubstreams.Module) error {
 				entrypoint:    entrypoint,
 				wasmArguments: inputs,
 				tracer:        tracer,
+				fuelBudget:    p.fuelBudget,
 			}
 
 			executor := &MapperModuleExecutor{
@@ -501,6 +620,22 @@ func (p *Pipeline) buildWASM(modules []*pbsubstreams.Module) error {
 				outputType:   outType,
 			}
 
+			p.moduleExecutors = append(p.moduleExecutors, executor)
+			continue
+		case *pbsubstreams.Module_KindIndex_:
+			// index modules run like maps, their output is recorded by `blockIndexes`
+			executor := &MapperModuleExecutor{
+				BaseExecutor: BaseExecutor{
+					moduleName:    module.Name,
+					wasmModule:    wasmModule,
+					entrypoint:    entrypoint,
+					wasmArguments: inputs,
+					tracer:        tracer,
+					fuelBudget:    p.fuelBudget,
+				},
+				outputType: strings.TrimPrefix(module.GetKindIndex().OutputType, "proto:"),
+			}
+
 			p.moduleExecutors = append(p.moduleExecutors, executor)
 			continue
 		case *pbsubstreams.Module_KindStore_:
@@ -519,6 +654,7 @@ func (p *Pipeline) buildWASM(modules []*pbsubstreams.Module) error {
 				entrypoint:    entrypoint,
 				wasmArguments: inputs,
 				tracer:        tracer,
+				fuelBudget:    p.fuelBudget,
 			}
 
 			s := &StoreModuleExecutor{
@@ -536,6 +672,38 @@ func (p *Pipeline) buildWASM(modules []*pbsubstreams.Module) error {
 	return nil
 }
 
+func (p *Pipeline) newNativeExecutor(module *pbsubstreams.Module, binaryName string, inputs []wasm.Argument, tracer ttrace.Tracer) (*NativeModuleExecutor, error) {
+	executor := &NativeModuleExecutor{
+		moduleName: module.Name,
+		arguments:  inputs,
+		tracer:     tracer,
+	}
+
+	var err error
+	switch module.Kind.(type) {
+	case *pbsubstreams.Module_KindMap_:
+		executor.outputType = strings.TrimPrefix(module.Output.GetType(), "proto:")
+		executor.mapHandler, err = p.nativeRegistry.Map(binaryName, module.BinaryEntrypoint)
+	case *pbsubstreams.Module_KindIndex_:
+		// index modules run like maps, their output is recorded by `blockIndexes`
+		executor.outputType = strings.TrimPrefix(module.GetKindIndex().OutputType, "proto:")
+		executor.mapHandler, err = p.nativeRegistry.Map(binaryName, module.BinaryEntrypoint)
+	case *pbsubstreams.Module_KindStore_:
+		outputStore, found := p.storeMap.Get(module.Name)
+		if !found {
+			return nil, fmt.Errorf("store %q not found", module.Name)
+		}
+		executor.outputStore = outputStore
+		executor.storeHandler, err = p.nativeRegistry.Store(binaryName, module.BinaryEntrypoint)
+	default:
+		return nil, fmt.Errorf("invalid kind %q", module.Kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return executor, nil
+}
+
 func (p *Pipeline) addStores(storeModules []*pbsubstreams.Module) error {
 	for _, storeModule := range storeModules {
 		store, err := p.storeFactory.NewFullKV(p.moduleHashes.Get(storeModule.Name), storeModule, p.reqCtx.logger)
@@ -585,5 +753,8 @@ func renderWasmInputs(module *pbsubstreams.Module, storeAccessor *store.Map) (ou
 			return nil, fmt.Errorf("invalid input struct for module %q", module.Name)
 		}
 	}
+	if module.Params != nil {
+		out = append(out, wasm.NewParamsInput(module.Params.Value))
+	}
 	return out, nil
 }
@@ -1,17 +1,24 @@
 package pipeline
 
 import (
+	"bytes"
 	"context"
 	"encoding/hex"
+	"fmt"
+	"github.com/bytecodealliance/wasmtime-go"
 	"github.com/streamingfast/bstream"
+	"github.com/streamingfast/dstore"
+	"github.com/streamingfast/substreams/native"
 	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
 	pbsubstreamstest "github.com/streamingfast/substreams/pb/sf/substreams/v1/test"
 	"github.com/streamingfast/substreams/pipeline/execout"
+	"github.com/streamingfast/substreams/store"
 	"github.com/streamingfast/substreams/wasm"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel"
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/proto"
+	"io"
 	"io/ioutil"
 	"testing"
 	"time"
@@ -58,6 +65,335 @@ func TestPipeline_runExecutor(t *testing.T) {
 	}
 }
 
+func TestPipeline_runExecutor_fuelBudget(t *testing.T) {
+	block := &pbsubstreamstest.Block{Id: "block-10", Number: 10, Step: int32(bstream.StepNewIrreversible)}
+	clock := &pbsubstreams.Clock{Id: block.Id, Number: block.Number}
+
+	t.Run("within budget", func(t *testing.T) {
+		executor := mapTestExecutorWithRuntime(t, wasm.NewRuntime(nil, wasm.WithFuelMetering()))
+		executor.fuelBudget = newFuelBudget(0, 100_000_000)
+		pipe := &Pipeline{reqCtx: testRequestContext(context.Background())}
+
+		execOutput := execout.NewExecOutputTesting(t, bstreamBlk(t, block), clock)
+		require.NoError(t, pipe.runExecutor(executor, execOutput))
+		require.NotZero(t, executor.fuelBudget.blockConsumed)
+		require.Less(t, executor.fuelBudget.blockConsumed, uint64(100_000_000))
+	})
+
+	t.Run("out of fuel", func(t *testing.T) {
+		executor := mapTestExecutorWithRuntime(t, wasm.NewRuntime(nil, wasm.WithFuelMetering()))
+		executor.fuelBudget = newFuelBudget(10, 0)
+
+		var responses []*pbsubstreams.Response
+		pipe := &Pipeline{
+			reqCtx: testRequestContext(context.Background()),
+			respFunc: func(resp *pbsubstreams.Response) error {
+				responses = append(responses, resp)
+				return nil
+			},
+		}
+
+		execOutput := execout.NewExecOutputTesting(t, bstreamBlk(t, block), clock)
+		err := pipe.runExecutor(executor, execOutput)
+		require.Error(t, err)
+
+		var outOfFuel *wasm.OutOfFuelError
+		require.ErrorAs(t, err, &outOfFuel)
+		require.Equal(t, uint64(10), outOfFuel.Limit)
+
+		require.Len(t, responses, 1)
+		failed := responses[0].GetProgress().Modules[0]
+		require.Equal(t, "map_test", failed.Name)
+		require.Contains(t, failed.GetFailed().Reason, `block 10: module "map_test": out of fuel`)
+	})
+}
+
+// failingExecutor fails every run with `err`.
+type failingExecutor struct {
+	err error
+}
+
+func (e *failingExecutor) Name() string   { return "failing" }
+func (e *failingExecutor) String() string { return "failing" }
+func (e *failingExecutor) Reset()         {}
+func (e *failingExecutor) run(ctx context.Context, reader execout.ExecutionOutputGetter) ([]byte, pbsubstreams.ModuleOutputData, error) {
+	return nil, nil, e.err
+}
+func (e *failingExecutor) applyCachedOutput(value []byte) error { return nil }
+func (e *failingExecutor) moduleLogs() ([]string, []*pbsubstreams.ModuleLog, bool) {
+	return nil, nil, false
+}
+func (e *failingExecutor) currentExecutionStack() []string { return nil }
+
+func TestPipeline_runExecutor_failureProgress(t *testing.T) {
+	block := &pbsubstreamstest.Block{Id: "block-10", Number: 10, Step: int32(bstream.StepNewIrreversible)}
+	clock := &pbsubstreams.Clock{Id: block.Id, Number: block.Number}
+
+	run := func(t *testing.T, err error) []*pbsubstreams.Response {
+		var responses []*pbsubstreams.Response
+		pipe := &Pipeline{
+			reqCtx: testRequestContext(context.Background()),
+			respFunc: func(resp *pbsubstreams.Response) error {
+				responses = append(responses, resp)
+				return nil
+			},
+		}
+		execOutput := execout.NewExecOutputTesting(t, bstreamBlk(t, block), clock)
+		require.ErrorIs(t, pipe.runExecutor(&failingExecutor{err: err}, execOutput), err)
+		return responses
+	}
+
+	// failed progress fails the sub-request without retry, transient errors must not send it
+	require.Len(t, run(t, fmt.Errorf("reading input: connection reset")), 0)
+
+	responses := run(t, &wasm.MemoryLimitError{Limit: 1024})
+	require.Len(t, responses, 1)
+	require.Equal(t, "failing", responses[0].GetProgress().Modules[0].Name)
+	require.NotNil(t, responses[0].GetProgress().Modules[0].GetFailed())
+
+	// panics and traps fail the same way on every retry
+	for _, err := range []error{
+		fmt.Errorf("block 10: %w", &wasm.PanicError{}),
+		fmt.Errorf("executing module: %w", wasmtime.NewTrap("unreachable")),
+	} {
+		responses := run(t, err)
+		require.Len(t, responses, 1)
+		require.NotNil(t, responses[0].GetProgress().Modules[0].GetFailed())
+	}
+}
+
+func TestPipeline_runExecutor_memoryLimit(t *testing.T) {
+	block := &pbsubstreamstest.Block{Id: "block-10", Number: 10, Step: int32(bstream.StepNewIrreversible)}
+	clock := &pbsubstreams.Clock{Id: block.Id, Number: block.Number}
+
+	// grows its memory until it fails, then aborts like an allocator does
+	code, err := wasmtime.Wat2Wasm(`(module
+  (memory (export "memory") 1)
+  (func (export "map_test")
+    (loop $grow
+      (br_if $grow (i32.ne (memory.grow (i32.const 1)) (i32.const -1))))
+    (unreachable)))`)
+	require.NoError(t, err)
+
+	wasmModule, err := wasm.NewRuntime(nil, wasm.WithMaxMemory(1024*1024)).NewModule(context.Background(), nil, wasm.BinaryTypeWASIV1, code, "map_test", "", "map_test")
+	require.NoError(t, err)
+	executor := &MapperModuleExecutor{
+		BaseExecutor: BaseExecutor{
+			moduleName:    "map_test",
+			wasmModule:    wasmModule,
+			wasmArguments: []wasm.Argument{wasm.NewBlockInput("sf.substreams.v1.test.Block")},
+			entrypoint:    "map_test",
+			tracer:        otel.GetTracerProvider().Tracer("test"),
+		},
+	}
+
+	var responses []*pbsubstreams.Response
+	pipe := &Pipeline{
+		reqCtx: testRequestContext(context.Background()),
+		respFunc: func(resp *pbsubstreams.Response) error {
+			responses = append(responses, resp)
+			return nil
+		},
+	}
+
+	execOutput := execout.NewExecOutputTesting(t, bstreamBlk(t, block), clock)
+	err = pipe.runExecutor(executor, execOutput)
+	var memoryLimit *wasm.MemoryLimitError
+	require.ErrorAs(t, err, &memoryLimit)
+	require.Equal(t, uint64(1024*1024), memoryLimit.Limit)
+	require.Equal(t, uint64(1024*1024), wasmModule.MemorySize())
+	require.Equal(t, wasmModule.MemorySize(), wasmModule.PeakMemorySize())
+
+	require.Len(t, responses, 1)
+	require.Contains(t, responses[0].GetProgress().Modules[0].GetFailed().Reason, `block 10: module "map_test": memory limit of 1.0 MiB reached`)
+}
+
+func TestPipeline_runExecutor_trapBacktrace(t *testing.T) {
+	block := &pbsubstreamstest.Block{Id: "block-10", Number: 10, Step: int32(bstream.StepNewIrreversible)}
+	clock := &pbsubstreams.Clock{Id: block.Id, Number: block.Number}
+
+	// `map_test` calls `my_crate::inner` which traps, DWARF line info maps them to `src/lib.rs`
+	cnt, err := ioutil.ReadFile("./testdata/trap_test.code.hex")
+	require.NoError(t, err)
+	code, err := hex.DecodeString(string(cnt))
+	require.NoError(t, err)
+
+	wasmModule, err := wasm.NewRuntime(nil).NewModule(context.Background(), nil, wasm.BinaryTypeWASIV1, code, "map_test", "", "map_test")
+	require.NoError(t, err)
+	executor := &MapperModuleExecutor{
+		BaseExecutor: BaseExecutor{
+			moduleName:    "map_test",
+			wasmModule:    wasmModule,
+			wasmArguments: []wasm.Argument{wasm.NewBlockInput("sf.substreams.v1.test.Block")},
+			entrypoint:    "map_test",
+			tracer:        otel.GetTracerProvider().Tracer("test"),
+		},
+	}
+
+	var responses []*pbsubstreams.Response
+	pipe := &Pipeline{
+		reqCtx: testRequestContext(context.Background()),
+		respFunc: func(resp *pbsubstreams.Response) error {
+			responses = append(responses, resp)
+			return nil
+		},
+	}
+
+	execOutput := execout.NewExecOutputTesting(t, bstreamBlk(t, block), clock)
+	err = pipe.runExecutor(executor, execOutput)
+	var panicErr *wasm.PanicError
+	require.ErrorAs(t, err, &panicErr)
+
+	require.Len(t, responses, 1)
+	failed := responses[0].GetProgress().Modules[0].GetFailed()
+	require.NotNil(t, failed)
+	require.Contains(t, failed.Reason, "at src/lib.rs:42:7")
+	require.Equal(t, []string{
+		"backtrace: #0 my_crate::inner (src/lib.rs:42:7)",
+		"backtrace: #1 map_test (src/lib.rs:50:3)",
+	}, failed.Logs)
+}
+
+func TestPipeline_runExecutor_native(t *testing.T) {
+	block := &pbsubstreamstest.Block{Id: "block-10", Number: 10, Step: int32(bstream.StepNewIrreversible)}
+	clock := &pbsubstreams.Clock{Id: block.Id, Number: block.Number}
+	tracer := otel.GetTracerProvider().Tracer("test")
+	blockInput := wasm.NewBlockInput("sf.substreams.v1.test.Block")
+
+	t.Run("map", func(t *testing.T) {
+		executor := &NativeModuleExecutor{
+			moduleName: "map_native",
+			arguments:  []wasm.Argument{blockInput, wasm.NewParamsInput("p")},
+			tracer:     tracer,
+			outputType: "sf.substreams.v1.test.MapResult",
+			mapHandler: func(ctx context.Context, clock *pbsubstreams.Clock, inputs []*native.Input) ([]byte, error) {
+				require.Len(t, inputs, 2)
+				require.NotEmpty(t, inputs[0].Value)
+				require.Equal(t, []byte("p"), inputs[1].Value)
+				return proto.Marshal(&pbsubstreamstest.MapResult{BlockNumber: clock.Number, BlockHash: clock.Id})
+			},
+		}
+		pipe := &Pipeline{reqCtx: testRequestContext(context.Background())}
+
+		execOutput := execout.NewExecOutputTesting(t, bstreamBlk(t, block), clock)
+		require.NoError(t, pipe.runExecutor(executor, execOutput))
+
+		out := &pbsubstreamstest.MapResult{}
+		require.NoError(t, proto.Unmarshal(execOutput.Values["map_native"], out))
+		assertProtoEqual(t, &pbsubstreamstest.MapResult{BlockNumber: 10, BlockHash: "block-10"}, out)
+	})
+
+	t.Run("store", func(t *testing.T) {
+		outputStore := store.NewTestKVStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", nil)
+		executor := &NativeModuleExecutor{
+			moduleName:  "store_native",
+			arguments:   []wasm.Argument{blockInput},
+			tracer:      tracer,
+			outputStore: outputStore,
+			storeHandler: func(ctx context.Context, clock *pbsubstreams.Clock, inputs []*native.Input, output store.Store) error {
+				output.Set(0, "last_block", clock.Id)
+				return nil
+			},
+		}
+		pipe := &Pipeline{reqCtx: testRequestContext(context.Background())}
+
+		execOutput := execout.NewExecOutputTesting(t, bstreamBlk(t, block), clock)
+		require.NoError(t, pipe.runExecutor(executor, execOutput))
+
+		value, found := outputStore.GetLast("last_block")
+		require.True(t, found)
+		require.Equal(t, "block-10", string(value))
+
+		deltas := &pbsubstreams.StoreDeltas{}
+		require.NoError(t, proto.Unmarshal(execOutput.Values["store_native"], deltas))
+		require.Len(t, deltas.Deltas, 1)
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		executor := &NativeModuleExecutor{
+			moduleName: "map_native",
+			arguments:  []wasm.Argument{blockInput},
+			tracer:     tracer,
+			mapHandler: func(ctx context.Context, clock *pbsubstreams.Clock, inputs []*native.Input) ([]byte, error) {
+				panic("boom")
+			},
+		}
+		pipe := &Pipeline{
+			reqCtx:   testRequestContext(context.Background()),
+			respFunc: func(resp *pbsubstreams.Response) error { return nil },
+		}
+
+		execOutput := execout.NewExecOutputTesting(t, bstreamBlk(t, block), clock)
+		err := pipe.runExecutor(executor, execOutput)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), `block 10: module "map_native": native handler panicked: boom`)
+	})
+
+	t.Run("index output type", func(t *testing.T) {
+		registry := native.NewRegistry()
+		registry.RegisterMap("bin", "v1", "index_native", func(ctx context.Context, clock *pbsubstreams.Clock, inputs []*native.Input) ([]byte, error) {
+			return nil, nil
+		})
+		pipe := &Pipeline{nativeRegistry: registry}
+
+		// index modules declare their output type on their kind, not on `Output`
+		module := &pbsubstreams.Module{
+			Name:             "index_native",
+			BinaryEntrypoint: "index_native",
+			Kind:             &pbsubstreams.Module_KindIndex_{KindIndex: &pbsubstreams.Module_KindIndex{OutputType: "proto:sf.substreams.index.v1.Keys"}},
+		}
+		executor, err := pipe.newNativeExecutor(module, "bin", nil, tracer)
+		require.NoError(t, err)
+		require.Equal(t, "sf.substreams.index.v1.Keys", executor.outputType)
+	})
+
+	t.Run("binary version", func(t *testing.T) {
+		registry := native.NewRegistry()
+		registry.RegisterMap("bin", "v1", "map_native", func(ctx context.Context, clock *pbsubstreams.Clock, inputs []*native.Input) ([]byte, error) {
+			return nil, nil
+		})
+
+		validate := func(content string) error {
+			reqCtx := testRequestContext(context.Background())
+			reqCtx.request = &pbsubstreams.Request{Modules: &pbsubstreams.Modules{
+				Binaries: []*pbsubstreams.Binary{{Type: native.BinaryTypeGoV1, Content: []byte(content)}},
+			}}
+			pipe := &Pipeline{reqCtx: reqCtx, nativeRegistry: registry}
+			return pipe.validateBinaries()
+		}
+
+		require.NoError(t, validate(native.BinaryContent("bin", "v1")))
+		require.EqualError(t, validate("bin@v2"), `native binary "bin" is registered at version "v1" on this server, not "v2"`)
+		require.EqualError(t, validate("other@v1"), `native binary "other" is not registered on this server`)
+		require.EqualError(t, validate("bin"), `native binary "bin": expected <name>@<version>`)
+	})
+}
+
+func TestFuelBudget_limit(t *testing.T) {
+	var unlimited *fuelBudget
+	_, limited := unlimited.limit()
+	require.False(t, limited)
+	require.Nil(t, newFuelBudget(0, 0))
+
+	budget := newFuelBudget(100, 250)
+	limit, limited := budget.limit()
+	require.True(t, limited)
+	require.Equal(t, uint64(100), limit)
+
+	budget.consumed(100)
+	budget.consumed(100)
+	limit, _ = budget.limit()
+	require.Equal(t, uint64(50), limit)
+
+	budget.consumed(60)
+	limit, _ = budget.limit()
+	require.Equal(t, uint64(0), limit)
+
+	budget.newBlock()
+	limit, _ = budget.limit()
+	require.Equal(t, uint64(100), limit)
+}
+
 func testRequestContext(ctx context.Context) *RequestContext {
 	return &RequestContext{
 		Context: ctx,
@@ -66,17 +402,23 @@ func testRequestContext(ctx context.Context) *RequestContext {
 }
 
 func mapTestExecutor(t *testing.T) *MapperModuleExecutor {
+	return mapTestExecutorWithRuntime(t, wasm.NewRuntime(nil))
+}
+
+func mapTestExecutorWithRuntime(t *testing.T, runtime *wasm.Runtime) *MapperModuleExecutor {
 	cnt, err := ioutil.ReadFile("./testdata/map_test.code.hex")
 	require.NoError(t, err)
 
 	code, err := hex.DecodeString(string(cnt))
 	require.NoError(t, err)
 
-	wasmModule, err := wasm.NewRuntime(nil).NewModule(
+	wasmModule, err := runtime.NewModule(
 		context.Background(),
 		nil,
+		wasm.BinaryTypeRustV1,
 		code,
 		"map_test",
+		"",
 		"map_test",
 	)
 	require.NoError(t, err)
@@ -126,3 +468,32 @@ func bstreamBlk(t *testing.T, blk *pbsubstreamstest.Block) *bstream.Block {
 
 	return bb
 }
+
+func TestPipeline_deleteCorruptedSnapshot(t *testing.T) {
+	ctx := context.Background()
+	fileStore, err := dstore.NewStore("file://"+t.TempDir(), "", "", false)
+	require.NoError(t, err)
+
+	kv := store.NewTestKVStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", fileStore)
+	kv.Set(0, "a", "1")
+	_, err = kv.Save(ctx, 10)
+	require.NoError(t, err)
+
+	// truncate the snapshot, like an interrupted upload
+	filename := "test.module.hash/states/0000000010-0000000000.kv"
+	r, err := fileStore.OpenObject(ctx, filename)
+	require.NoError(t, err)
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	require.NoError(t, fileStore.WriteObject(ctx, filename, bytes.NewReader(data[:len(data)-3])))
+
+	pipe := &Pipeline{reqCtx: testRequestContext(ctx)}
+	loaded := store.NewTestKVStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", fileStore)
+	err = pipe.deleteCorruptedSnapshot(loaded, loaded.Load(ctx, 10))
+	require.ErrorIs(t, err, store.ErrCorruptedSnapshot)
+
+	exists, err := fileStore.FileExists(ctx, filename)
+	require.NoError(t, err)
+	require.False(t, exists, "corrupted snapshot deleted")
+}
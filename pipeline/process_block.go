@@ -95,6 +95,10 @@ func (p *Pipeline) handleStepMatchesNew(block *bstream.Block, clock *pbsubstream
 		return fmt.Errorf("failed to flush stores: %w", err)
 	}
 
+	if err = p.blockIndexes.newBlock(p.reqCtx, block.Num(), step.Matches(bstream.StepIrreversible)); err != nil {
+		return fmt.Errorf("failed to roll block indexes: %w", err)
+	}
+
 	if isStopBlockReached(clock.Number, p.reqCtx.StopBlockNum()) {
 		// TODO: should we not flush the cache only in IRR
 		//	p.reqCtx.logger.Debug("about to save cache output",
@@ -104,6 +108,9 @@ func (p *Pipeline) handleStepMatchesNew(block *bstream.Block, clock *pbsubstream
 		//	if err = p.cachingEngine.Flush(p.reqCtx.Context()); err != nil {
 		//		return fmt.Errorf("failed to flush cache engines: %w", err)
 		//	}
+		if err = p.returnModuleTimings(true); err != nil {
+			return fmt.Errorf("failed to return modules timings: %w", err)
+		}
 		return io.EOF
 	}
 
@@ -117,6 +124,10 @@ func (p *Pipeline) handleStepMatchesNew(block *bstream.Block, clock *pbsubstream
 		}
 	}
 
+	if err = p.returnModuleTimings(false); err != nil {
+		return fmt.Errorf("failed to return modules timings: %w", err)
+	}
+
 	if shouldReturnDataOutputs(clock.Number, p.reqCtx.StartBlockNum(), p.reqCtx.isSubRequest) {
 		p.reqCtx.logger.Debug("will return module outputs")
 
@@ -140,6 +151,7 @@ func (p *Pipeline) executeModules(execOutput execout.ExecutionOutput) (err error
 	p.reqCtx.StartSpan("modules_executions", p.tracer)
 	defer p.reqCtx.EndSpan(err)
 
+	p.fuelBudget.newBlock()
 	for _, executor := range p.moduleExecutors {
 		if err = p.runExecutor(executor, execOutput); err != nil {
 			return err
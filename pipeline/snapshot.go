@@ -6,6 +6,12 @@ import (
 	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
 )
 
+// snapshotBatchSize is the number of keys sent in each `InitialSnapshotData`.
+const snapshotBatchSize = 100
+
+// sendSnapshots streams the stores requested in `InitialStoreSnapshotForModules`
+// as they are iterated, in lexical order of their keys, holding at most one
+// batch of keys. `SentKeys` then tells how far in the order a client got.
 func (p *Pipeline) sendSnapshots() error {
 	snapshotModules := p.reqCtx.Request().InitialStoreSnapshotForModules
 	if len(snapshotModules) == 0 {
@@ -19,7 +25,7 @@ func (p *Pipeline) sendSnapshots() error {
 			return fmt.Errorf("store %q not found", modName)
 		}
 
-		send := func(count uint64, total uint64, deltas []*pbsubstreams.StoreDelta) {
+		send := func(count uint64, total uint64, deltas []*pbsubstreams.StoreDelta) error {
 			data := &pbsubstreams.InitialSnapshotData{
 				ModuleName: modName,
 				Deltas: &pbsubstreams.StoreDeltas{
@@ -28,34 +34,39 @@ func (p *Pipeline) sendSnapshots() error {
 				SentKeys:  count,
 				TotalKeys: total,
 			}
-			p.respFunc(substreams.NewSnapshotData(data))
+			return p.respFunc(substreams.NewSnapshotData(data))
 		}
 
 		var count uint64
 		total := store.Length()
-		var accum []*pbsubstreams.StoreDelta
+		batch := make([]*pbsubstreams.StoreDelta, 0, snapshotBatchSize)
 
-		store.Iter(func(k string, v []byte) error {
+		err := store.Iter(func(k string, v []byte) error {
 			count++
-			accum = append(accum, &pbsubstreams.StoreDelta{
+			batch = append(batch, &pbsubstreams.StoreDelta{
 				Operation: pbsubstreams.StoreDelta_CREATE,
 				Key:       k,
 				NewValue:  v,
 			})
 
-			if count%100 == 0 {
-				send(count, total, accum)
-				accum = nil
+			if len(batch) == snapshotBatchSize {
+				if err := send(count, total, batch); err != nil {
+					return err
+				}
+				batch = make([]*pbsubstreams.StoreDelta, 0, snapshotBatchSize)
 			}
 			return nil
 		})
+		if err != nil {
+			return fmt.Errorf("sending snapshot of store %q: %w", modName, err)
+		}
 
-		if len(accum) != 0 {
-			send(count, total, accum)
+		if len(batch) != 0 {
+			if err := send(count, total, batch); err != nil {
+				return fmt.Errorf("sending snapshot of store %q: %w", modName, err)
+			}
 		}
 	}
 
-	p.respFunc(substreams.NewSnapshotComplete())
-
-	return nil
+	return p.respFunc(substreams.NewSnapshotComplete())
 }
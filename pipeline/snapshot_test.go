@@ -0,0 +1,56 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/streamingfast/substreams/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPipeline_sendSnapshots(t *testing.T) {
+	kvStore := store.NewTestKVStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", nil)
+	for i := 0; i < 250; i++ {
+		kvStore.Set(uint64(i), fmt.Sprintf("key%03d", i), "value")
+	}
+	storeMap := store.NewMap()
+	storeMap.Set("store_a", kvStore)
+
+	reqCtx := NewRequestContext(context.Background(), &pbsubstreams.Request{InitialStoreSnapshotForModules: []string{"store_a"}}, false)
+
+	var responses []*pbsubstreams.Response
+	pipe := &Pipeline{
+		reqCtx:   reqCtx,
+		storeMap: storeMap,
+		respFunc: func(resp *pbsubstreams.Response) error {
+			responses = append(responses, resp)
+			return nil
+		},
+	}
+	require.NoError(t, pipe.sendSnapshots())
+
+	require.Len(t, responses, 4)
+	var keys []string
+	for i, expectedSent := range []uint64{100, 200, 250} {
+		data := responses[i].GetSnapshotData()
+		require.NotNil(t, data)
+		assert.Equal(t, "store_a", data.ModuleName)
+		assert.Equal(t, expectedSent, data.SentKeys)
+		assert.Equal(t, uint64(250), data.TotalKeys)
+		for _, delta := range data.Deltas.Deltas {
+			keys = append(keys, delta.Key)
+		}
+	}
+	require.Len(t, keys, 250)
+	assert.True(t, sort.StringsAreSorted(keys), "keys sent in lexical order")
+	assert.NotNil(t, responses[3].GetSnapshotComplete())
+
+	pipe.respFunc = func(resp *pbsubstreams.Response) error {
+		return fmt.Errorf("client gone")
+	}
+	assert.EqualError(t, pipe.sendSnapshots(), `sending snapshot of store "store_a": client gone`)
+}
@@ -0,0 +1,77 @@
+package pipeline
+
+import (
+	"time"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+)
+
+const timingsReportInterval = 5 * time.Second
+
+// moduleTimings accumulates the wall time spent on each module between two
+// ProcessedTimings progress messages. A nil *moduleTimings records nothing.
+type moduleTimings struct {
+	interval time.Duration
+	lastSent time.Time
+
+	modules []string // in order of first observation
+	timings map[string]*pbsubstreams.ModuleProgress_ProcessedTimings
+}
+
+func newModuleTimings(interval time.Duration) *moduleTimings {
+	return &moduleTimings{
+		interval: interval,
+		lastSent: time.Now(),
+		timings:  map[string]*pbsubstreams.ModuleProgress_ProcessedTimings{},
+	}
+}
+
+func (t *moduleTimings) observe(module string, cached bool, elapsed time.Duration) {
+	if t == nil {
+		return
+	}
+
+	timing, found := t.timings[module]
+	if !found {
+		timing = &pbsubstreams.ModuleProgress_ProcessedTimings{}
+		t.timings[module] = timing
+		t.modules = append(t.modules, module)
+	}
+
+	nanos := uint64(elapsed.Nanoseconds())
+	if cached {
+		timing.CacheHits++
+		timing.CacheHitTimeNs += nanos
+		return
+	}
+	timing.Executions++
+	timing.ExecutionTimeNs += nanos
+	if nanos > timing.MaxExecutionTimeNs {
+		timing.MaxExecutionTimeNs = nanos
+	}
+}
+
+// progress returns what was accumulated and starts over, if the interval
+// elapsed since the last call or `force` is set.
+func (t *moduleTimings) progress(now time.Time, force bool) (out []*pbsubstreams.ModuleProgress) {
+	if t == nil || len(t.modules) == 0 {
+		return nil
+	}
+	if !force && now.Sub(t.lastSent) < t.interval {
+		return nil
+	}
+
+	for _, module := range t.modules {
+		out = append(out, &pbsubstreams.ModuleProgress{
+			Name: module,
+			Type: &pbsubstreams.ModuleProgress_ProcessedTimings_{
+				ProcessedTimings: t.timings[module],
+			},
+		})
+	}
+
+	t.lastSent = now
+	t.modules = nil
+	t.timings = map[string]*pbsubstreams.ModuleProgress_ProcessedTimings{}
+	return out
+}
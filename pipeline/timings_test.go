@@ -0,0 +1,46 @@
+package pipeline
+
+import (
+	"testing"
+	"time"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModuleTimings(t *testing.T) {
+	var disabled *moduleTimings
+	disabled.observe("map_a", false, time.Second)
+	assert.Nil(t, disabled.progress(time.Now(), true))
+
+	timings := newModuleTimings(time.Minute)
+	start := timings.lastSent
+
+	timings.observe("map_a", false, 3*time.Millisecond)
+	timings.observe("store_b", true, time.Microsecond)
+	timings.observe("map_a", false, 5*time.Millisecond)
+	timings.observe("map_a", true, 2*time.Microsecond)
+
+	assert.Nil(t, timings.progress(start.Add(time.Second), false))
+
+	progress := timings.progress(start.Add(time.Minute), false)
+	require.Len(t, progress, 2)
+	assert.Equal(t, "map_a", progress[0].Name)
+	assertProtoEqual(t, &pbsubstreams.ModuleProgress_ProcessedTimings{
+		Executions:         2,
+		ExecutionTimeNs:    uint64(8 * time.Millisecond),
+		MaxExecutionTimeNs: uint64(5 * time.Millisecond),
+		CacheHits:          1,
+		CacheHitTimeNs:     uint64(2 * time.Microsecond),
+	}, progress[0].GetProcessedTimings())
+	assert.Equal(t, "store_b", progress[1].Name)
+	assert.Equal(t, uint64(1), progress[1].GetProcessedTimings().CacheHits)
+
+	assert.Nil(t, timings.progress(start.Add(2*time.Minute), true), "nothing observed since last progress")
+
+	timings.observe("map_a", false, time.Millisecond)
+	progress = timings.progress(start.Add(time.Minute+time.Second), true)
+	require.Len(t, progress, 1)
+	assert.Equal(t, uint64(1), progress[0].GetProcessedTimings().Executions)
+}
@@ -1,7 +1,10 @@
 package service
 
 import (
+	"github.com/streamingfast/substreams/compress"
+	"github.com/streamingfast/substreams/native"
 	"github.com/streamingfast/substreams/pipeline"
+	"github.com/streamingfast/substreams/store"
 	"github.com/streamingfast/substreams/wasm"
 )
 
@@ -13,6 +16,71 @@ func WithWASMExtension(ext wasm.WASMExtensioner) Option {
 	}
 }
 
+// WithWASMCompilationCacheDir persists compiled WASM modules in `dir`, so a
+// restarted process does not have to compile them again.
+func WithWASMCompilationCacheDir(dir string) Option {
+	return func(s *Service) {
+		s.wasmCompilationCacheDir = dir
+	}
+}
+
+// WithWASMModuleCacheSize bounds the number of compiled WASM modules kept in
+// memory, the least recently used ones being evicted,
+// `wasm.DefaultModuleCacheSize` by default.
+func WithWASMModuleCacheSize(size int) Option {
+	return func(s *Service) {
+		s.wasmModuleCacheSize = size
+	}
+}
+
+// WithFuelBudget enables wasmtime fuel metering and bounds the fuel a single
+// module execution (`perModule`) and all module executions of a block
+// (`perBlock`) may consume. Zero means unlimited. A module running out of fuel
+// fails the request.
+func WithFuelBudget(perModule, perBlock uint64) Option {
+	return func(s *Service) {
+		s.fuelPerModule = perModule
+		s.fuelPerBlock = perBlock
+	}
+}
+
+// WithWASMMaxMemory bounds the linear memory of each WASM module to `bytes`,
+// a module growing past it fails the request.
+func WithWASMMaxMemory(bytes uint64) Option {
+	return func(s *Service) {
+		s.wasmMaxMemory = bytes
+	}
+}
+
+// WithWASMExtensionCache persists the results of the WASM extensions calls in
+// the state store, under `extensions/`, one object per block keyed by input.
+// Blocks processed again then get the same results without calling the
+// extensions. `New` fails without a state store.
+func WithWASMExtensionCache() Option {
+	return func(s *Service) {
+		s.wasmExtensionCache = true
+	}
+}
+
+// WithNativeRegistry serves the Go handlers of `registry` to modules whose
+// binary is of type `native/go-v1`. Every server of a deployment, including
+// the ones processing sub-requests, must be given the same handlers.
+func WithNativeRegistry(registry *native.Registry) Option {
+	return func(s *Service) {
+		s.nativeRegistry = registry
+	}
+}
+
+// WithoutStoreValueValidation accepts store values that are malformed for the
+// value type declared by their module, like a non-numeric string written to
+// an `int64` store. By default, writing or merging such a value fails the
+// request, naming the key.
+func WithoutStoreValueValidation() Option {
+	return func(s *Service) {
+		s.skipStoreValueValidation = true
+	}
+}
+
 func WithPipelineOptions(f pipeline.PipelineOptioner) Option {
 	return func(s *Service) {
 		s.pipelineOptions = append(s.pipelineOptions, f)
@@ -36,3 +104,57 @@ func WithOutCacheSaveInterval(block uint64) Option {
 		s.outputCacheSaveBlockInterval = block
 	}
 }
+
+// WithStoresCompression sets the compression of the store snapshots, none by
+// default. Snapshots are read whatever their compression, but compressed ones
+// cannot be read by previous versions.
+func WithStoresCompression(compression compress.Compression) Option {
+	return func(s *Service) {
+		s.storesCompression = compression
+	}
+}
+
+// WithStoresCheckpointInterval makes full stores save a full snapshot every
+// `interval` snapshots, `store.DefaultCheckpointInterval` by default, the
+// others being delta segments holding the keys changed since the previous
+// snapshot. An interval of 1 saves full snapshots only.
+func WithStoresCheckpointInterval(interval uint64) Option {
+	return func(s *Service) {
+		s.storesCheckpointInterval = interval
+	}
+}
+
+// WithDiskStores holds the stores selected by `config` in an on-disk KV
+// engine instead of memory, for states larger than RAM.
+func WithDiskStores(config store.DiskConfig) Option {
+	return func(s *Service) {
+		s.diskStores = &config
+	}
+}
+
+// WithOutCacheCompression sets the compression of the output cache files,
+// none by default. Compressed files cannot be read by previous versions.
+func WithOutCacheCompression(compression compress.Compression) Option {
+	return func(s *Service) {
+		s.outputCacheCompression = compression
+	}
+}
+
+// WithOutCachePrefetch loads the next segment of the output cache in the
+// background once the blocks pass `threshold`, a fraction of the current
+// segment, `execout.DefaultPrefetchThreshold` by default. Zero disables it.
+func WithOutCachePrefetch(threshold float64) Option {
+	return func(s *Service) {
+		s.outputCachePrefetch = threshold
+	}
+}
+
+// WithOutCacheV2 writes the output cache with the `cachev2` engine, as
+// protobuf segments indexed by block number instead of JSON. It still reads
+// the files written by `cachev1`, which can't read its segments: enable it
+// once no `cachev1` instance shares the state store.
+func WithOutCacheV2() Option {
+	return func(s *Service) {
+		s.outputCacheV2 = true
+	}
+}
@@ -2,18 +2,22 @@ package service
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
 	"github.com/streamingfast/bstream/hub"
 	dgrpcserver "github.com/streamingfast/dgrpc/server"
 	"github.com/streamingfast/dstore"
 	"github.com/streamingfast/logging"
 	"github.com/streamingfast/substreams/client"
+	"github.com/streamingfast/substreams/compress"
 	"github.com/streamingfast/substreams/errors"
+	"github.com/streamingfast/substreams/native"
 	"github.com/streamingfast/substreams/orchestrator"
 	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
 	"github.com/streamingfast/substreams/pipeline"
 	"github.com/streamingfast/substreams/pipeline/execout"
 	"github.com/streamingfast/substreams/pipeline/execout/cachev1"
+	"github.com/streamingfast/substreams/pipeline/execout/cachev2"
 	"github.com/streamingfast/substreams/store"
 	"github.com/streamingfast/substreams/wasm"
 	"go.opentelemetry.io/otel"
@@ -32,6 +36,15 @@ type Service struct {
 	blockType                 string
 	partialModeEnabled        bool
 	wasmExtensions            []wasm.WASMExtensioner
+	wasmCompilationCacheDir   string
+	wasmModuleCacheSize       int
+	wasmRuntime               *wasm.Runtime
+	fuelPerModule             uint64
+	fuelPerBlock              uint64
+	wasmMaxMemory             uint64
+	wasmExtensionCache        bool
+	nativeRegistry            *native.Registry
+	skipStoreValueValidation  bool
 	pipelineOptions           []pipeline.PipelineOptioner
 	streamFactory             *StreamFactory
 	workerPool                *orchestrator.WorkerPool
@@ -41,6 +54,12 @@ type Service struct {
 	// properties of cache
 	storesSaveInterval           uint64
 	outputCacheSaveBlockInterval uint64
+	storesCompression            compress.Compression
+	outputCacheCompression       compress.Compression
+	outputCacheV2                bool
+	outputCachePrefetch          float64
+	diskStores                   *store.DiskConfig
+	storesCheckpointInterval     uint64
 	baseStateStore               dstore.Store
 
 	tracer ttrace.Tracer
@@ -60,6 +79,10 @@ func New(
 		blockType:                 blockType,
 		parallelSubRequests:       parallelSubRequests,
 		blockRangeSizeSubRequests: blockRangeSizeSubRequests,
+		storesCompression:         compress.Default,
+		outputCacheCompression:    compress.Default,
+		outputCachePrefetch:       execout.DefaultPrefetchThreshold,
+		storesCheckpointInterval:  store.DefaultCheckpointInterval,
 		tracer:                    otel.GetTracerProvider().Tracer("service"),
 	}
 
@@ -74,6 +97,29 @@ func New(
 		opt(s)
 	}
 
+	runtimeOpts := []wasm.RuntimeOption{
+		wasm.WithCompilationCacheDir(s.wasmCompilationCacheDir),
+		wasm.WithModuleCacheSize(s.wasmModuleCacheSize),
+		wasm.WithMaxMemory(s.wasmMaxMemory),
+	}
+	if s.fuelPerModule != 0 || s.fuelPerBlock != 0 {
+		runtimeOpts = append(runtimeOpts, wasm.WithFuelMetering())
+	}
+	if s.wasmExtensionCache {
+		if stateStore == nil {
+			return nil, fmt.Errorf("wasm extension cache requires a state store")
+		}
+		extensionStore, err := stateStore.SubStore("extensions")
+		if err != nil {
+			return nil, fmt.Errorf("creating wasm extension cache store: %w", err)
+		}
+		runtimeOpts = append(runtimeOpts, wasm.WithExtensionCache(wasm.NewExtensionCache(extensionStore)))
+	}
+	if s.skipStoreValueValidation {
+		runtimeOpts = append(runtimeOpts, wasm.WithoutStoreValueValidation())
+	}
+	s.wasmRuntime = wasm.NewRuntime(s.wasmExtensions, runtimeOpts...)
+
 	return s, nil
 }
 
@@ -144,6 +190,12 @@ func (s *Service) blocks(ctx context.Context, request *pbsubstreams.Request, str
 			opts = append(opts, opt)
 		}
 	}
+	if s.fuelPerModule != 0 || s.fuelPerBlock != 0 {
+		opts = append(opts, pipeline.WithFuelBudget(s.fuelPerModule, s.fuelPerBlock))
+	}
+	if s.nativeRegistry != nil {
+		opts = append(opts, pipeline.WithNativeRegistry(s.nativeRegistry))
+	}
 
 	/*
 		this entire `if` is not good, the ctx is from the StreamServer so there
@@ -173,21 +225,41 @@ func (s *Service) blocks(ctx context.Context, request *pbsubstreams.Request, str
 
 	requestCtx := pipeline.NewRequestContext(ctx, request, isSubrequest)
 	storeGenerator := pipeline.NewStoreFactory(s.baseStateStore, s.storesSaveInterval)
+	if s.skipStoreValueValidation {
+		storeGenerator.DisableValueValidation()
+	}
+	storeGenerator.SetCompression(s.storesCompression)
+	storeGenerator.SetCheckpointInterval(s.storesCheckpointInterval)
+	if s.diskStores != nil {
+		storeGenerator.SetDiskConfig(s.diskStores)
+	}
 	storeBoundary := pipeline.NewStoreBoundary(s.storesSaveInterval)
 	cachingEngine := execout.NewNoOpCache()
 	if s.baseStateStore != nil {
-		cachingEngine, err = cachev1.NewEngine(context.Background(), s.outputCacheSaveBlockInterval, s.baseStateStore, requestCtx.Logger())
+		newCachingEngine := cachev1.NewEngine
+		if s.outputCacheV2 {
+			newCachingEngine = cachev2.NewEngine
+		}
+		cachingEngine, err = newCachingEngine(requestCtx, s.outputCacheSaveBlockInterval, s.outputCacheCompression, s.baseStateStore, requestCtx.Logger())
 		if err != nil {
 			return errors.NewBasicErr(status.Errorf(grpccode.Internal, "error building caching engine: %s", err), err)
 		}
+		if prefetcher, ok := cachingEngine.(execout.Prefetcher); ok {
+			prefetcher.EnablePrefetch(s.outputCachePrefetch)
+		}
 	}
 
 	storeMap := store.NewMap()
+	defer func() {
+		if err := storeMap.Close(); err != nil {
+			logger.Warn("closing stores", zap.Error(err))
+		}
+	}()
 	pipe := pipeline.New(
 		requestCtx,
 		graph,
 		s.blockType,
-		s.wasmExtensions,
+		s.wasmRuntime,
 		s.blockRangeSizeSubRequests,
 		cachingEngine,
 		storeMap,
@@ -198,6 +270,10 @@ func (s *Service) blocks(ctx context.Context, request *pbsubstreams.Request, str
 	)
 
 	if err := pipe.Init(s.workerPool); err != nil {
+		if stderrors.Is(err, store.ErrCorruptedSnapshot) {
+			// the corrupted snapshot is deleted, the parent request plans the work again instead of retrying
+			return errors.NewBasicErr(status.Errorf(grpccode.DataLoss, "error building pipeline: %s", err), err)
+		}
 		return errors.NewBasicErr(status.Errorf(grpccode.Internal, "error building pipeline: %s", err), err)
 	}
 
@@ -24,6 +24,8 @@ func validateGraph(request *pbsubstreams.Request, blockType string) (*manifest.M
 		return nil, fmt.Errorf("validate request: %s", err)
 	}
 
+	manifest.ApplyParams(request.Params, request.Modules)
+
 	graph, err := manifest.NewModuleGraph(request.Modules.Modules)
 	if err != nil {
 		return nil, fmt.Errorf("creating module graph from request: %s", err)
@@ -3,6 +3,7 @@ package store
 import (
 	"fmt"
 	"github.com/streamingfast/dstore"
+	"github.com/streamingfast/substreams/compress"
 	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -17,13 +18,21 @@ type BaseStore struct {
 	moduleInitialBlock uint64
 	//storeInitialBlock  uint64 // block at which we initialized this store
 
-	kv     map[string][]byte          // kv is the state, and assumes all deltas were already applied to it.
+	kv     kvStore                    // kv is the state, and assumes all deltas were already applied to it.
 	deltas []*pbsubstreams.StoreDelta // deltas are always deltas for the given block.
 
 	updatePolicy pbsubstreams.Module_KindStore_UpdatePolicy
 	valueType    string
 	lastOrdinal  uint64
 	logger       *zap.Logger
+
+	skipValueValidation bool // see `DisableValueValidation`
+	compression         compress.Compression
+	disk                *DiskConfig // see `SetDiskConfig`
+	checkpointInterval  uint64      // see `SetCheckpointInterval`
+	failure             error       // see `Err`
+
+	changes *snapshotChanges // keys changed since the last snapshot of a full store, nil when not tracked
 }
 
 func NewBaseStore(name string, moduleInitialBlock uint64, moduleHash string, updatePolicy pbsubstreams.Module_KindStore_UpdatePolicy, valueType string, store dstore.Store, logger *zap.Logger) (*BaseStore, error) {
@@ -34,17 +43,131 @@ func NewBaseStore(name string, moduleInitialBlock uint64, moduleHash string, upd
 
 	return &BaseStore{
 		name:               name,
-		kv:                 make(map[string][]byte),
+		kv:                 newMemoryKV(nil),
 		updatePolicy:       updatePolicy,
 		valueType:          valueType,
 		store:              subStore,
 		moduleHash:         moduleHash,
 		moduleInitialBlock: moduleInitialBlock,
+		compression:        compress.Default,
 		logger:             logger.Named("store").With(zap.String("store_name", name)),
 	}, nil
 
 }
 
+// DisableValueValidation makes `Merge` accept values malformed for the
+// store's value type, instead of failing on the first one.
+func (s *BaseStore) DisableValueValidation() {
+	s.skipValueValidation = true
+}
+
+// SetCompression sets the compression of the snapshots saved afterwards.
+// Snapshots are loaded whatever their compression.
+func (s *BaseStore) SetCompression(compression compress.Compression) {
+	s.compression = compression
+}
+
+// SetDiskConfig holds the store in an on-disk KV engine, right away when its
+// module is selected by `config`, or once it exceeds the spill threshold.
+func (s *BaseStore) SetDiskConfig(config *DiskConfig) error {
+	s.disk = config
+	if !config.selects(s.name) {
+		return nil
+	}
+	kv, err := s.moveToDisk(s.kv)
+	if err != nil {
+		return err
+	}
+	s.kv = kv
+	return nil
+}
+
+// OnDisk tells if the store is held in an on-disk KV engine.
+func (s *BaseStore) OnDisk() bool {
+	_, onDisk := s.kv.(*diskKV)
+	return onDisk
+}
+
+// Close releases the on-disk KV engine of the store, if any.
+func (s *BaseStore) Close() error {
+	if s.kv == nil {
+		return nil
+	}
+	return s.kv.close()
+}
+
+// Err returns the first failure of the on-disk KV engine holding the store,
+// like a full disk or a failed commit. Store writes cannot return errors, so
+// it is returned by the next `Save`, `Load` or `Merge` instead.
+func (s *BaseStore) Err() error {
+	if s.failure != nil {
+		return s.failure
+	}
+	if err := s.kv.err(); err != nil {
+		return fmt.Errorf("store %q: %w", s.name, err)
+	}
+	return nil
+}
+
+// replaceKV swaps the kv of the store for `kv`, releasing the previous one.
+func (s *BaseStore) replaceKV(kv kvStore) {
+	s.Close()
+	s.kv = kv
+}
+
+// newKV returns an empty kv, on disk when the disk config selects the module.
+func (s *BaseStore) newKV() (kvStore, error) {
+	if s.disk != nil && s.disk.selects(s.name) {
+		return newDiskKV(s.disk.Dir)
+	}
+	return newMemoryKV(nil), nil
+}
+
+// spill returns `kv` moved to disk when it is in memory while the disk config
+// selects the module, or exceeds the spill threshold, `kv` itself otherwise.
+func (s *BaseStore) spill(kv kvStore) kvStore {
+	if s.disk == nil || s.failure != nil {
+		return kv
+	}
+	if _, inMemory := kv.(*memoryKV); !inMemory {
+		return kv
+	}
+	overThreshold := s.disk.SpillThreshold != 0 && kv.size() > s.disk.SpillThreshold
+	if !overThreshold && !s.disk.selects(s.name) {
+		return kv
+	}
+
+	s.logger.Info("moving store to disk", zap.Uint64("key_count", kv.len()), zap.Uint64("size", kv.size()), zap.Uint64("spill_threshold", s.disk.SpillThreshold))
+	diskKV, err := s.moveToDisk(kv)
+	if err != nil {
+		if s.failure == nil {
+			s.failure = fmt.Errorf("store %q: moving to disk: %w", s.name, err)
+		}
+		return kv
+	}
+	return diskKV
+}
+
+func (s *BaseStore) moveToDisk(kv kvStore) (kvStore, error) {
+	if _, onDisk := kv.(*diskKV); onDisk {
+		return kv, nil
+	}
+	diskKV, err := newDiskKV(s.disk.Dir)
+	if err != nil {
+		return nil, err
+	}
+	kv.iter(func(key string, value []byte) error {
+		diskKV.set(key, value)
+		return nil
+	})
+	if err := diskKV.err(); err != nil {
+		diskKV.close()
+		return nil, err
+	}
+	kv.close()
+	return diskKV, nil
+}
+
 func (s *BaseStore) Name() string { return s.name }
 
 func (s *BaseStore) InitialBlock() uint64 { return s.moduleInitialBlock }
@@ -57,14 +180,15 @@ func (s *BaseStore) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	enc.AddString("name", s.name)
 	enc.AddString("hash", s.moduleHash)
 	enc.AddUint64("module_initial_block", s.moduleInitialBlock)
-	enc.AddInt("key_count", len(s.kv))
+	enc.AddUint64("key_count", s.kv.len())
+	enc.AddBool("on_disk", s.OnDisk())
 
 	return nil
 }
 
 func (s *BaseStore) Reset() {
 	if tracer.Enabled() {
-		s.logger.Debug("flushing store", zap.String("name", s.name), zap.Int("delta_count", len(s.deltas)), zap.Int("entry_count", len(s.kv)))
+		s.logger.Debug("flushing store", zap.String("name", s.name), zap.Int("delta_count", len(s.deltas)), zap.Uint64("entry_count", s.kv.len()))
 	}
 	s.deltas = nil
 	s.lastOrdinal = 0
@@ -47,6 +47,50 @@ func TestStore(t *testing.T) {
 	assert.True(t, found)
 }
 
+func TestStore_Has(t *testing.T) {
+	s := NewTestKVStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_UNSET, "", nil)
+
+	s.Set(0, "existing", "val1")
+	s.Reset()
+	s.Set(2, "created", "val2")
+	s.DeletePrefix(4, "existing")
+
+	assert.True(t, s.HasFirst("existing"))
+	assert.False(t, s.HasFirst("created"))
+	assert.False(t, s.HasLast("existing"))
+	assert.True(t, s.HasLast("created"))
+
+	assert.False(t, s.HasAt(1, "created"))
+	assert.True(t, s.HasAt(2, "created"))
+	assert.True(t, s.HasAt(3, "existing"))
+	assert.False(t, s.HasAt(4, "existing"))
+	assert.False(t, s.HasLast("unknown"))
+}
+
+func TestStore_ScanPrefix(t *testing.T) {
+	s := NewTestKVStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_UNSET, "", nil)
+
+	s.Set(0, "pair:c", "3")
+	s.Set(1, "pair:a", "1")
+	s.Set(2, "token:a", "t")
+	s.Set(3, "pair:b", "2")
+	s.Set(4, "pair", "0")
+
+	scan := func(prefix string, limit uint64) (out []string) {
+		err := s.ScanPrefix(prefix, limit, func(key string, value []byte) error {
+			out = append(out, key+"="+string(value))
+			return nil
+		})
+		require.NoError(t, err)
+		return
+	}
+
+	assert.Equal(t, []string{"pair:a=1", "pair:b=2", "pair:c=3"}, scan("pair:", 0))
+	assert.Equal(t, []string{"pair=0", "pair:a=1"}, scan("pair", 2))
+	assert.Equal(t, []string{"pair=0", "pair:a=1", "pair:b=2", "pair:c=3", "token:a=t"}, scan("", 0))
+	assert.Nil(t, scan("unknown", 0))
+}
+
 func TestFileName(t *testing.T) {
 	prefix := fullStateFilePrefix(10000)
 	require.Equal(t, "0000010000", prefix)
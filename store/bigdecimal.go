@@ -0,0 +1,66 @@
+package store
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+)
+
+// bigdecimal values are exact base 10 numbers, held as `big.Rat` so sums,
+// and thus merged partials, never round. They are stored in plain decimal
+// notation without trailing zeros, like `-12.5`.
+
+var bigDecimalRegexp = regexp.MustCompile(`^[+-]?(\d+(\.\d*)?|\.\d+)([eE][+-]?\d{1,4})?$`)
+
+// ParseBigDecimal parses a decimal number like `-12.50` or `1.25e-3`.
+func ParseBigDecimal(in string) (*big.Rat, error) {
+	if !bigDecimalRegexp.MatchString(in) {
+		return nil, fmt.Errorf("invalid bigdecimal value %q", in)
+	}
+	out, ok := new(big.Rat).SetString(in)
+	if !ok {
+		return nil, fmt.Errorf("invalid bigdecimal value %q", in)
+	}
+	return out, nil
+}
+
+// BigDecimalToString formats `value`, which must have a denominator made of
+// factors 2 and 5 only, as do all the sums of parsed decimals.
+func BigDecimalToString(value *big.Rat) string {
+	denom := new(big.Int).Set(value.Denom())
+
+	twos := denom.TrailingZeroBits()
+	denom.Rsh(denom, twos)
+
+	var fives uint
+	five, mod := big.NewInt(5), new(big.Int)
+	for denom.Cmp(big.NewInt(1)) > 0 {
+		quo, _ := new(big.Int).QuoRem(denom, five, mod)
+		if mod.Sign() != 0 {
+			panic(fmt.Sprintf("value %s is not a decimal", value))
+		}
+		denom = quo
+		fives++
+	}
+
+	digits := twos
+	if fives > digits {
+		digits = fives
+	}
+	return value.FloatString(int(digits))
+}
+
+func strToBigDecimal(in string) *big.Rat {
+	out, err := ParseBigDecimal(in)
+	if err != nil {
+		panic(fmt.Sprintf("cannot load decimal: %s", err))
+	}
+	return out
+}
+
+func foundOrZeroBigDecimal(in []byte, found bool) *big.Rat {
+	if !found {
+		return new(big.Rat)
+	}
+	return strToBigDecimal(string(in))
+}
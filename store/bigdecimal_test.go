@@ -0,0 +1,72 @@
+package store
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBigDecimal(t *testing.T) {
+	tests := []struct {
+		in          string
+		expect      string
+		expectError bool
+	}{
+		{"0", "0", false},
+		{"-12.50", "-12.5", false},
+		{"+.5", "0.5", false},
+		{"7.", "7", false},
+		{"1.25e-3", "0.00125", false},
+		{"1.5E2", "150", false},
+		{"123456789012345678901234567890.000000000000000000001", "123456789012345678901234567890.000000000000000000001", false},
+		{"1/3", "", true},
+		{"0x10", "", true},
+		{"Inf", "", true},
+		{"1e99999", "", true},
+		{"", "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.in, func(t *testing.T) {
+			value, err := ParseBigDecimal(test.in)
+			if test.expectError {
+				require.EqualError(t, err, fmt.Sprintf("invalid bigdecimal value %q", test.in))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expect, BigDecimalToString(value))
+		})
+	}
+}
+
+// Summing a sequence of values linearly, or in partials later merged, must
+// give the exact same value.
+func TestStoreSumBigDecimal_mergeMatchesLinear(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	values := make([]string, 300)
+	for i := range values {
+		values[i] = fmt.Sprintf("%d.%02d", random.Intn(2000)-1000, random.Intn(100))
+	}
+
+	linear := newTestBaseStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_ADD, OutputValueTypeBigDecimal, nil)
+	for i, value := range values {
+		linear.SumBigDecimal(uint64(i), "key", strToBigDecimal(value))
+	}
+
+	merged := NewTestKVStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_ADD, OutputValueTypeBigDecimal, nil)
+	for start := 0; start < len(values); start += 100 {
+		partial := NewTestKVPartialStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_ADD, OutputValueTypeBigDecimal, nil, uint64(start))
+		for i, value := range values[start : start+100] {
+			partial.SumBigDecimal(uint64(i), "key", strToBigDecimal(value))
+		}
+		require.NoError(t, merged.Merge(partial))
+	}
+
+	expected, _ := linear.GetLast("key")
+	actual, _ := merged.GetLast("key")
+	assert.Equal(t, string(expected), string(actual))
+}
@@ -1,34 +1,111 @@
 package store
 
 import (
-	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"github.com/streamingfast/derr"
 	"github.com/streamingfast/dstore"
+	"github.com/streamingfast/substreams/compress"
 	"io"
 )
 
-func saveStore(ctx context.Context, store dstore.Store, filename string, content []byte) error {
-	return derr.RetryContext(ctx, 3, func(ctx context.Context) error {
-		return store.WriteObject(ctx, filename, bytes.NewReader(content))
+var errWriteObjectAborted = errors.New("write object aborted")
+
+// saveStore streams what `write` writes, compressed, to `filename`, without
+// holding the whole file in memory. Failures to write the file are retried,
+// `write` being called again, but not the errors of `write` itself.
+func saveStore(ctx context.Context, store dstore.Store, filename string, compression compress.Compression, write func(w io.Writer) error) error {
+	var writeErr error
+	err := derr.RetryContext(ctx, 3, func(ctx context.Context) error {
+		pr, pw := io.Pipe()
+		writeDone := make(chan error, 1)
+		go func() {
+			writeDone <- writeCompressed(pw, compression, write)
+		}()
+
+		err := store.WriteObject(ctx, filename, pr)
+		pr.CloseWithError(errWriteObjectAborted)
+		if err := <-writeDone; err != nil && !errors.Is(err, errWriteObjectAborted) {
+			writeErr = err
+			return nil
+		}
+		return err
 	})
+	if err != nil {
+		return err
+	}
+	return writeErr
+}
+
+func writeCompressed(pw *io.PipeWriter, compression compress.Compression, write func(w io.Writer) error) (err error) {
+	defer func() {
+		pw.CloseWithError(err)
+	}()
+
+	w, err := compression.NewWriter(pw)
+	if err != nil {
+		return fmt.Errorf("compressing with %s: %w", compression, err)
+	}
+	if err := write(w); err != nil {
+		return err
+	}
+	return w.Close()
 }
 
-func loadStore(ctx context.Context, store dstore.Store, filename string) (out []byte, err error) {
-	err = derr.RetryContext(ctx, 3, func(ctx context.Context) error {
+// loadStore decompresses `filename` and streams it to `read`. Failures to
+// read the file are retried, but not the errors of `read` itself, like a
+// corrupted content.
+func loadStore(ctx context.Context, store dstore.Store, filename string, read func(r io.Reader) error) error {
+	var readErr error
+	err := derr.RetryContext(ctx, 3, func(ctx context.Context) error {
 		r, err := store.OpenObject(ctx, filename)
 		if err != nil {
 			return fmt.Errorf("openning file: %w", err)
 		}
-		data, err := io.ReadAll(r)
+		defer r.Close()
+
+		object := &objectReader{Reader: r}
+		decompressed, err := compress.NewReader(object)
 		if err != nil {
-			return fmt.Errorf("reading data: %w", err)
+			if object.err != nil {
+				return fmt.Errorf("reading data: %w", err)
+			}
+			readErr = corrupted(fmt.Errorf("decompressing: %w", err))
+			return nil
 		}
-		defer r.Close()
+		defer decompressed.Close()
 
-		out = data
+		if err := read(decompressed); err != nil {
+			if object.err != nil {
+				return fmt.Errorf("reading data: %w", object.err)
+			}
+			readErr = err
+		}
 		return nil
 	})
-	return out, err
+	if err != nil {
+		return err
+	}
+
+	var corruptedErr *corruptedSnapshotError
+	if errors.As(readErr, &corruptedErr) && corruptedErr.filename == "" {
+		corruptedErr.filename = filename
+	}
+	return readErr
+}
+
+// objectReader keeps the error of the underlying object, to tell it apart
+// from the decoding ones.
+type objectReader struct {
+	io.Reader
+	err error
+}
+
+func (r *objectReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	if err != nil && err != io.EOF {
+		r.err = err
+	}
+	return n, err
 }
@@ -0,0 +1,76 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/streamingfast/dstore"
+	"github.com/streamingfast/substreams/compress"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveStore_streaming(t *testing.T) {
+	var written []byte
+	writeCalls := 0
+	store := dstore.NewMockStore(func(base string, f io.Reader) error {
+		writeCalls++
+		if writeCalls == 1 {
+			io.CopyN(io.Discard, f, 10)
+			return fmt.Errorf("connection reset")
+		}
+		var err error
+		written, err = io.ReadAll(f)
+		return err
+	})
+
+	data := bytes.Repeat([]byte("some snapshot content "), 10000)
+	err := saveStore(context.Background(), store, "file", compress.Compression{Codec: compress.Zstd}, func(w io.Writer) error {
+		for i := 0; i < len(data); i += 1000 {
+			if _, err := w.Write(data[i : i+1000]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, writeCalls)
+
+	decompressed, err := compress.Decompress(written)
+	require.NoError(t, err)
+	assert.Equal(t, data, decompressed)
+}
+
+func TestSaveStore_writeError(t *testing.T) {
+	writeCalls := 0
+	store := dstore.NewMockStore(func(base string, f io.Reader) error {
+		writeCalls++
+		_, err := io.ReadAll(f)
+		return err
+	})
+
+	err := saveStore(context.Background(), store, "file", compress.Default, func(w io.Writer) error {
+		return fmt.Errorf("marshal failed")
+	})
+	assert.EqualError(t, err, "marshal failed")
+	assert.Equal(t, 1, writeCalls)
+}
+
+func TestLoadStore_readError(t *testing.T) {
+	openCalls := 0
+	store := dstore.NewMockStore(nil)
+	store.OpenObjectFunc = func(ctx context.Context, name string) (io.ReadCloser, error) {
+		openCalls++
+		return io.NopCloser(bytes.NewReader([]byte("corrupted"))), nil
+	}
+
+	err := loadStore(context.Background(), store, "file", func(r io.Reader) error {
+		io.ReadAll(r)
+		return fmt.Errorf("unmarshal data: invalid")
+	})
+	assert.EqualError(t, err, "unmarshal data: invalid")
+	assert.Equal(t, 1, openCalls)
+}
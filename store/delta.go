@@ -16,20 +16,32 @@ func (s *BaseStore) ApplyDelta(delta *pbsubstreams.StoreDelta) {
 
 	switch delta.Operation {
 	case pbsubstreams.StoreDelta_UPDATE, pbsubstreams.StoreDelta_CREATE:
-		s.kv[delta.Key] = delta.NewValue
+		s.setKV(delta.Key, delta.NewValue)
 	case pbsubstreams.StoreDelta_DELETE:
-		delete(s.kv, delta.Key)
+		s.deleteKV(delta.Key)
 	}
 }
 
+// setKV sets `key` in the state, moving it to disk when needed.
+func (s *BaseStore) setKV(key string, value []byte) {
+	s.kv.set(key, value)
+	s.kv = s.spill(s.kv)
+	s.changes.add(key)
+}
+
+func (s *BaseStore) deleteKV(key string) {
+	s.kv.delete(key)
+	s.changes.add(key)
+}
+
 func (s *BaseStore) ApplyDeltasReverse(deltas []*pbsubstreams.StoreDelta) {
 	for i := len(deltas) - 1; i >= 0; i-- {
 		delta := deltas[i]
 		switch delta.Operation {
 		case pbsubstreams.StoreDelta_UPDATE, pbsubstreams.StoreDelta_DELETE:
-			s.kv[delta.Key] = delta.OldValue
+			s.setKV(delta.Key, delta.OldValue)
 		case pbsubstreams.StoreDelta_CREATE:
-			delete(s.kv, delta.Key)
+			s.deleteKV(delta.Key)
 		}
 	}
 }
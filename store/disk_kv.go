@@ -0,0 +1,305 @@
+package store
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"runtime"
+	"sort"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// DiskConfig selects the stores held in an on-disk KV engine instead of
+// memory, for states larger than RAM. On-disk stores are scratch files,
+// removed when the store is closed: snapshots are still saved to the state
+// store.
+type DiskConfig struct {
+	// Dir is where on-disk stores are created, the OS temporary directory
+	// when empty.
+	Dir string
+	// Modules are the names of the store modules always held on disk.
+	Modules []string
+	// SpillThreshold moves the other stores to disk once their keys and
+	// values exceed that many bytes. Zero keeps them in memory.
+	SpillThreshold uint64
+}
+
+func (c *DiskConfig) selects(moduleName string) bool {
+	for _, name := range c.Modules {
+		if name == moduleName {
+			return true
+		}
+	}
+	return false
+}
+
+var diskKVBucket = []byte("kv")
+
+// bbolt keys can be neither empty nor longer than `bolt.MaxKeySize`, unlike
+// store keys: the bbolt key of a store key is the store key prefixed with
+// `diskKVShortKey`, or for longer keys, its hash prefixed with
+// `diskKVLongKey`, the value then starting with the store key.
+const (
+	diskKVShortKey byte = 0x00
+	diskKVLongKey  byte = 0x01
+)
+
+func diskKVKey(key string) []byte {
+	if len(key) < bolt.MaxKeySize {
+		return append([]byte{diskKVShortKey}, key...)
+	}
+	sum := sha256.Sum256([]byte(key))
+	return append([]byte{diskKVLongKey}, sum[:]...)
+}
+
+func encodeDiskKVLongValue(key string, value []byte) []byte {
+	out := make([]byte, binary.MaxVarintLen64+len(key)+len(value))
+	n := binary.PutUvarint(out, uint64(len(key)))
+	n += copy(out[n:], key)
+	n += copy(out[n:], value)
+	return out[:n]
+}
+
+func decodeDiskKVLongValue(stored []byte) (key string, value []byte) {
+	length, n := binary.Uvarint(stored)
+	return string(stored[n : n+int(length)]), stored[n+int(length):]
+}
+
+// diskKVBatchSize is the number of writes after which the pending write
+// transaction is committed, bounding the dirty pages held in memory.
+const diskKVBatchSize = 10_000
+
+// diskKV keeps the keys in a bbolt B+tree, so they iterate sorted, the rare
+// keys too long for bbolt being sorted on iteration. All
+// accesses go through a single long-lived write transaction, committed every
+// `diskKVBatchSize` writes without syncing, durability being pointless for
+// a scratch file.
+type diskKV struct {
+	db     *bolt.DB
+	path   string
+	tx     *bolt.Tx
+	bucket *bolt.Bucket
+
+	pendingWrites int
+	count         uint64
+	bytes         uint64
+
+	// failure is the first write error, like a full disk, after which the
+	// kv is neither read nor written anymore.
+	failure error
+}
+
+func newDiskKV(dir string) (*diskKV, error) {
+	f, err := os.CreateTemp(dir, "substreams-store-*.db")
+	if err != nil {
+		return nil, fmt.Errorf("creating disk store file: %w", err)
+	}
+	f.Close()
+
+	db, err := bolt.Open(f.Name(), 0600, &bolt.Options{NoSync: true, NoGrowSync: true, NoFreelistSync: true})
+	if err != nil {
+		os.Remove(f.Name())
+		return nil, fmt.Errorf("opening disk store %q: %w", f.Name(), err)
+	}
+
+	d := &diskKV{db: db, path: f.Name()}
+	if err := d.begin(); err != nil {
+		d.close()
+		return nil, err
+	}
+
+	// stores dropped without being closed, like clones, still remove their file
+	runtime.SetFinalizer(d, func(d *diskKV) { d.close() })
+	return d, nil
+}
+
+func (d *diskKV) begin() (err error) {
+	d.tx, err = d.db.Begin(true)
+	if err != nil {
+		return fmt.Errorf("disk store %q: begin transaction: %w", d.path, err)
+	}
+	d.bucket, err = d.tx.CreateBucketIfNotExists(diskKVBucket)
+	if err != nil {
+		return fmt.Errorf("disk store %q: create bucket: %w", d.path, err)
+	}
+	d.pendingWrites = 0
+	return nil
+}
+
+// fail records `err` as the failure of the kv, the first one only.
+func (d *diskKV) fail(err error) {
+	if d.failure == nil {
+		d.failure = err
+	}
+}
+
+func (d *diskKV) wrote() {
+	d.pendingWrites++
+	if d.pendingWrites < diskKVBatchSize {
+		return
+	}
+	if err := d.tx.Commit(); err != nil {
+		d.tx = nil
+		d.fail(fmt.Errorf("disk store %q: commit: %w", d.path, err))
+		return
+	}
+	if err := d.begin(); err != nil {
+		d.fail(err)
+	}
+}
+
+// lookup returns the value of `key` as held by bbolt, only valid until the
+// next write.
+func (d *diskKV) lookup(key string) ([]byte, bool) {
+	if d.failure != nil {
+		return nil, false
+	}
+	k := diskKVKey(key)
+	v := d.bucket.Get(k)
+	if v == nil {
+		return nil, false
+	}
+	if k[0] == diskKVLongKey {
+		_, v = decodeDiskKVLongValue(v)
+	}
+	return v, true
+}
+
+func (d *diskKV) get(key string) ([]byte, bool) {
+	value, found := d.lookup(key)
+	if !found {
+		return nil, false
+	}
+	return copyBytes(value), true
+}
+
+func (d *diskKV) set(key string, value []byte) {
+	if d.failure != nil {
+		return
+	}
+	prev, found := d.lookup(key)
+	prevSize := uint64(len(key) + len(prev))
+
+	k := diskKVKey(key)
+	var stored []byte
+	if k[0] == diskKVLongKey {
+		stored = encodeDiskKVLongValue(key, value)
+	} else {
+		// bbolt references the value until the transaction is committed
+		stored = copyBytes(value)
+	}
+	if err := d.bucket.Put(k, stored); err != nil {
+		d.fail(fmt.Errorf("disk store %q: set key %q: %w", d.path, key, err))
+		return
+	}
+	if found {
+		d.bytes -= prevSize
+	} else {
+		d.count++
+	}
+	d.bytes += uint64(len(key) + len(value))
+	d.wrote()
+}
+
+func (d *diskKV) delete(key string) {
+	if d.failure != nil {
+		return
+	}
+	prev, found := d.lookup(key)
+	if !found {
+		return
+	}
+	prevSize := uint64(len(key) + len(prev))
+	if err := d.bucket.Delete(diskKVKey(key)); err != nil {
+		d.fail(fmt.Errorf("disk store %q: delete key %q: %w", d.path, key, err))
+		return
+	}
+	d.count--
+	d.bytes -= prevSize
+	d.wrote()
+}
+
+func (d *diskKV) len() uint64  { return d.count }
+func (d *diskKV) size() uint64 { return d.bytes }
+
+func (d *diskKV) iter(f func(key string, value []byte) error) error {
+	return d.iterRange("", "", f)
+}
+
+func (d *diskKV) iterRange(low, high string, f func(key string, value []byte) error) error {
+	if d.failure != nil {
+		return d.failure
+	}
+	long := d.longKeysInRange(low, high)
+
+	c := d.bucket.Cursor()
+	for k, v := c.Seek(append([]byte{diskKVShortKey}, low...)); k != nil && k[0] == diskKVShortKey; k, v = c.Next() {
+		key := string(k[1:])
+		if high != "" && key >= high {
+			break
+		}
+		for len(long) > 0 && long[0].key < key {
+			if err := f(long[0].key, long[0].value); err != nil {
+				return err
+			}
+			long = long[1:]
+		}
+		if err := f(key, copyBytes(v)); err != nil {
+			return err
+		}
+	}
+	for _, entry := range long {
+		if err := f(entry.key, entry.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type diskKVEntry struct {
+	key   string
+	value []byte
+}
+
+// longKeysInRange returns the entries of the keys too long for bbolt within
+// [low, high), sorted. Their bbolt keys being hashes, all are read.
+func (d *diskKV) longKeysInRange(low, high string) (out []diskKVEntry) {
+	c := d.bucket.Cursor()
+	for k, v := c.Seek([]byte{diskKVLongKey}); k != nil && k[0] == diskKVLongKey; k, v = c.Next() {
+		key, value := decodeDiskKVLongValue(v)
+		if key < low || (high != "" && key >= high) {
+			continue
+		}
+		out = append(out, diskKVEntry{key: key, value: copyBytes(value)})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].key < out[j].key })
+	return out
+}
+
+func (d *diskKV) err() error { return d.failure }
+
+func (d *diskKV) close() error {
+	if d.db == nil {
+		return nil
+	}
+	runtime.SetFinalizer(d, nil)
+
+	if d.tx != nil {
+		d.tx.Rollback()
+		d.tx = nil
+	}
+	err := d.db.Close()
+	d.db = nil
+	if removeErr := os.Remove(d.path); err == nil && removeErr != nil {
+		err = removeErr
+	}
+	return err
+}
+
+func copyBytes(in []byte) []byte {
+	out := make([]byte, len(in))
+	copy(out, in)
+	return out
+}
@@ -0,0 +1,165 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	bolt "go.etcd.io/bbolt"
+)
+
+// testDiskDir is set while `forEachKVBackend` runs its disk variant, making
+// `newTestKV` and `useTestBackend` create on-disk kvs.
+var testDiskDir string
+
+// forEachKVBackend runs the semantics tests `f` against the in-memory and
+// the on-disk kvs.
+func forEachKVBackend(t *testing.T, f func(t *testing.T)) {
+	t.Run("memory", f)
+	t.Run("disk", func(t *testing.T) {
+		testDiskDir = t.TempDir()
+		defer func() { testDiskDir = "" }()
+		f(t)
+	})
+}
+
+func newTestKV(kv map[string][]byte) kvStore {
+	if testDiskDir == "" {
+		return newMemoryKV(kv)
+	}
+	d, err := newDiskKV(testDiskDir)
+	if err != nil {
+		panic(err)
+	}
+	for key, value := range kv {
+		d.set(key, value)
+	}
+	return d
+}
+
+func useTestBackend(t *testing.T, s *BaseStore) {
+	t.Helper()
+	if testDiskDir != "" {
+		require.NoError(t, s.SetDiskConfig(&DiskConfig{Dir: testDiskDir, Modules: []string{s.name}}))
+		require.True(t, s.OnDisk())
+	}
+}
+
+func kvMap(kv kvStore) map[string][]byte {
+	out := map[string][]byte{}
+	kv.iter(func(key string, value []byte) error {
+		out[key] = value
+		return nil
+	})
+	return out
+}
+
+func TestDiskKV(t *testing.T) {
+	d, err := newDiskKV(t.TempDir())
+	require.NoError(t, err)
+
+	// past a batch, so some writes are committed
+	for i := 0; i < diskKVBatchSize+10; i++ {
+		d.set(fmt.Sprintf("key%05d", i), []byte("v"))
+	}
+	d.set("key00000", []byte("value"))
+	d.delete("key00001")
+	d.delete("absent")
+	d.set("empty", nil)
+
+	assert.Equal(t, uint64(diskKVBatchSize+10), d.len())
+	assert.Equal(t, uint64(diskKVBatchSize+10)*9, d.size())
+
+	value, found := d.get("key00000")
+	assert.True(t, found)
+	assert.Equal(t, "value", string(value))
+	_, found = d.get("key00001")
+	assert.False(t, found)
+	value, found = d.get("empty")
+	assert.True(t, found)
+	assert.Len(t, value, 0)
+
+	var keys []string
+	require.NoError(t, d.iterRange("key0000", prefixEnd("key0000"), func(key string, _ []byte) error {
+		keys = append(keys, key)
+		return nil
+	}))
+	assert.Equal(t, []string{"key00000", "key00002", "key00003", "key00004", "key00005", "key00006", "key00007", "key00008", "key00009"}, keys)
+
+	require.NoError(t, d.close())
+	_, err = os.Stat(d.path)
+	assert.True(t, os.IsNotExist(err))
+	require.NoError(t, d.close())
+}
+
+func TestBaseStore_diskSpill(t *testing.T) {
+	dir := t.TempDir()
+	s := NewTestKVStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", nil)
+	require.NoError(t, s.SetDiskConfig(&DiskConfig{Dir: dir, SpillThreshold: 20}))
+	assert.False(t, s.OnDisk())
+
+	s.Set(0, "a", "0123456789")
+	assert.False(t, s.OnDisk())
+	s.Set(1, "b", "0123456789")
+	assert.True(t, s.OnDisk())
+
+	assert.Equal(t, uint64(2), s.Length())
+	value, found := s.GetLast("a")
+	require.True(t, found)
+	assert.Equal(t, "0123456789", string(value))
+
+	s.ApplyDeltasReverse(s.GetDeltas())
+	assert.Equal(t, uint64(0), s.Length())
+
+	require.NoError(t, s.Close())
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, files, 0)
+}
+
+func TestFullKV_diskCloneAndLoad(t *testing.T) {
+	ctx := context.Background()
+	fileStore := newTestFileStore(t)
+	config := &DiskConfig{Dir: t.TempDir(), Modules: []string{"test"}}
+
+	s := NewTestKVStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", fileStore)
+	require.NoError(t, s.SetDiskConfig(config))
+	s.Set(0, "b", "2")
+	s.Set(1, "a", "1")
+	_, err := s.Save(ctx, 100)
+	require.NoError(t, err)
+
+	clone := s.Clone()
+	require.NoError(t, clone.Load(ctx, 100))
+	assert.True(t, clone.OnDisk())
+	assert.Equal(t, map[string]string{"a": "1", "b": "2"}, kvStrings(clone.kv))
+
+	empty := s.Clone()
+	empty.Set(0, "c", "3")
+	assert.True(t, empty.OnDisk())
+}
+
+func TestFullKV_diskFailure(t *testing.T) {
+	ctx := context.Background()
+	s := NewTestKVStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", newTestFileStore(t))
+	require.NoError(t, s.SetDiskConfig(&DiskConfig{Dir: t.TempDir(), Modules: []string{"test"}}))
+	s.Set(0, "a", "1")
+	require.NoError(t, s.Err())
+
+	// the commit of the pending writes fails, like on a full disk
+	disk := s.kv.(*diskKV)
+	disk.pendingWrites = diskKVBatchSize - 1
+	require.NoError(t, disk.tx.Rollback())
+	disk.wrote()
+	s.Set(1, "b", "2")
+
+	require.Error(t, s.Err())
+	_, err := s.Save(ctx, 100)
+	assert.ErrorIs(t, err, bolt.ErrTxClosed)
+	assert.ErrorIs(t, s.Merge(NewTestKVPartialStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", nil, 0)), bolt.ErrTxClosed)
+	s.Close()
+}
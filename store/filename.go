@@ -11,7 +11,7 @@ import (
 var stateFileRegex *regexp.Regexp
 
 func init() {
-	stateFileRegex = regexp.MustCompile(`([\d]+)-([\d]+)\.(kv|partial)`)
+	stateFileRegex = regexp.MustCompile(`([\d]+)-([\d]+)\.(kv|partial|delta)`)
 }
 
 type FileInfo struct {
@@ -19,6 +19,7 @@ type FileInfo struct {
 	StartBlock uint64
 	EndBlock   uint64
 	Partial    bool
+	Delta      bool // delta segment, from the snapshot ending at `StartBlock`
 }
 
 func parseFileName(filename string) (*FileInfo, bool) {
@@ -30,12 +31,14 @@ func parseFileName(filename string) (*FileInfo, bool) {
 	end := uint64(utils.MustAtoi(res[0][1]))
 	start := uint64(utils.MustAtoi(res[0][2]))
 	partial := res[0][3] == "partial"
+	delta := res[0][3] == "delta"
 
 	return &FileInfo{
 		Filename:   filename,
 		StartBlock: start,
 		EndBlock:   end,
 		Partial:    partial,
+		Delta:      delta,
 	}, true
 }
 
@@ -50,3 +53,15 @@ func partialFileName(r *block.Range) string {
 func fullStateFileName(r *block.Range) string {
 	return fmt.Sprintf("%010d-%010d.kv", r.ExclusiveEndBlock, r.StartBlock)
 }
+
+func deltaFileName(r *block.Range) string {
+	return fmt.Sprintf("%010d-%010d.delta", r.ExclusiveEndBlock, r.StartBlock)
+}
+
+func snapshotFileInfo(r *block.Range, partial bool) *FileInfo {
+	filename := fullStateFileName(r)
+	if partial {
+		filename = partialFileName(r)
+	}
+	return &FileInfo{Filename: filename, StartBlock: r.StartBlock, EndBlock: r.ExclusiveEndBlock, Partial: partial}
+}
@@ -2,8 +2,8 @@ package store
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"io"
 	"github.com/streamingfast/dstore"
 	"github.com/streamingfast/substreams/block"
 	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
@@ -38,10 +38,15 @@ func (s *FullKV) Clone() *FullKV {
 		store:              s.store,
 		moduleInitialBlock: s.moduleInitialBlock,
 		moduleHash:         s.moduleHash,
-		kv:                 map[string][]byte{},
+		kv:                 newMemoryKV(nil), // moved to disk on the first write when needed
 		updatePolicy:       s.updatePolicy,
 		valueType:          s.valueType,
 		logger:             s.logger,
+
+		skipValueValidation: s.skipValueValidation,
+		compression:         s.compression,
+		disk:                s.disk,
+		checkpointInterval:  s.checkpointInterval,
 	}
 	return &FullKV{b}
 }
@@ -50,20 +55,17 @@ func (s *FullKV) storageFilename(exclusiveEndBlock uint64) string {
 	return fullStateFileName(block.NewRange(s.moduleInitialBlock, exclusiveEndBlock))
 }
 
+// Load reconstructs the state at `exclusiveEndBlock`, from its full snapshot,
+// or from the nearest full checkpoint and the delta segments following it.
 func (s *FullKV) Load(ctx context.Context, exclusiveEndBlock uint64) error {
 	fileName := s.storageFilename(exclusiveEndBlock)
 	s.logger.Debug("loading full store state from file", zap.String("module_name", s.name), zap.String("fileName", fileName))
 
-	data, err := loadStore(ctx, s.store, fileName)
+	depth, err := s.loadChain(ctx, exclusiveEndBlock)
 	if err != nil {
 		return fmt.Errorf("load full store %s at %s: %w", s.name, fileName, err)
 	}
-
-	kv := map[string][]byte{}
-	if err = json.Unmarshal(data, &kv); err != nil {
-		return fmt.Errorf("unmarshal data: %w", err)
-	}
-	s.kv = kv
+	s.trackChanges(exclusiveEndBlock, depth)
 
 	s.logger.Debug("full store loaded", zap.String("store_name", s.name), zap.String("fileName", fileName))
 	return nil
@@ -74,18 +76,33 @@ func (s *FullKV) Load(ctx context.Context, exclusiveEndBlock uint64) error {
 // boundary.
 func (s *FullKV) Save(ctx context.Context, endBoundaryBlock uint64) (*block.Range, error) {
 	s.logger.Debug("writing full store state", zap.Object("store", s))
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	brange := block.NewRange(s.moduleInitialBlock, endBoundaryBlock)
 
-	content, err := json.MarshalIndent(s.kv, "", "  ")
-	if err != nil {
-		return nil, fmt.Errorf("marshal kv state: %w", err)
+	if s.nextSnapshotIsDelta(endBoundaryBlock) {
+		filename, err := s.saveDelta(ctx, endBoundaryBlock)
+		if err != nil {
+			return nil, fmt.Errorf("write delta of store %q at block %d: %w", s.name, endBoundaryBlock, err)
+		}
+		s.logger.Info("full store delta written",
+			zap.String("store", s.name),
+			zap.String("file_name", filename),
+			zap.Object("block_range", brange),
+		)
+		return brange, nil
 	}
 
 	filename := s.storageFilename(endBoundaryBlock)
-	if err := saveStore(ctx, s.store, filename, content); err != nil {
+	err := saveStore(ctx, s.store, filename, s.compression, func(w io.Writer) error {
+		return writeSnapshot(w, s.snapshotHeader(false), s.kv)
+	})
+	if err != nil {
 		return nil, fmt.Errorf("write fill store %q in file %q: %w", s.name, filename, err)
 	}
+	s.trackChanges(endBoundaryBlock, 0)
 
-	brange := block.NewRange(s.moduleInitialBlock, endBoundaryBlock)
 	s.logger.Info("full store state written",
 		zap.String("store", s.name),
 		zap.String("file_name", filename),
@@ -97,7 +114,7 @@ func (s *FullKV) Save(ctx context.Context, endBoundaryBlock uint64) (*block.Rang
 
 func (s *FullKV) Reset() {
 	if tracer.Enabled() {
-		s.logger.Debug("flushing store", zap.String("name", s.name), zap.Int("delta_count", len(s.deltas)), zap.Int("entry_count", len(s.kv)))
+		s.logger.Debug("flushing store", zap.String("name", s.name), zap.Int("delta_count", len(s.deltas)), zap.Uint64("entry_count", s.kv.len()))
 	}
 	s.deltas = nil
 	s.lastOrdinal = 0
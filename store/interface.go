@@ -17,6 +17,8 @@ type Store interface {
 	Iterable
 	DeltaAccessor
 	Resetable
+	Closer
+	Failable
 
 	// intrinsics
 	Reader
@@ -30,16 +32,19 @@ type Store interface {
 	MaxInt64Setter
 	MaxFloat64Setter
 	MaxBigFloatSetter
+	MaxBigDecimalSetter
 
 	MinBigIntSetter
 	MinInt64Setter
 	MinFloat64Setter
 	MinBigFloatSetter
+	MinBigDecimalSetter
 
 	SumBigIntSetter
 	SumInt64Setter
 	SumFloat64Setter
 	SumBigFloatSetter
+	SumBigDecimalSetter
 }
 
 type PartialStore interface {
@@ -62,9 +67,24 @@ type Resetable interface {
 	Reset()
 }
 
+// Closer releases the resources of a store, like its on-disk KV engine.
+type Closer interface {
+	Close() error
+}
+
+// Failable reports the failure of the KV engine holding a store, like a full
+// disk, after which its state cannot be trusted.
+type Failable interface {
+	Err() error
+}
+
+// Iterable iterates over the keys of a store in their last state, in lexical
+// order, so the iteration is deterministic.
 type Iterable interface {
 	Length() uint64
 	Iter(func(key string, value []byte) error) error
+	IterRange(low, high string, f func(key string, value []byte) error) error
+	IterPrefix(prefix string, f func(key string, value []byte) error) error
 }
 
 type DeltaAccessor interface {
@@ -77,6 +97,14 @@ type Reader interface {
 	GetFirst(key string) ([]byte, bool)
 	GetLast(key string) ([]byte, bool)
 	GetAt(ord uint64, key string) ([]byte, bool)
+
+	HasFirst(key string) bool
+	HasLast(key string) bool
+	HasAt(ord uint64, key string) bool
+
+	// ScanPrefix calls `f` for the keys starting with `prefix` in their last
+	// state, in lexical order, stopping after `limit` keys unless it is 0.
+	ScanPrefix(prefix string, limit uint64, f func(key string, value []byte) error) error
 }
 
 type UpdateKeySetter interface {
@@ -94,9 +122,10 @@ type Appender interface {
 }
 
 type Deleter interface {
+	Del(ord uint64, key string)
 	DeletePrefix(ord uint64, prefix string)
-	//// Deletes a range of keys, lexicographically between `lowKey` and `highKey`
-	//DeleteRange(lowKey, highKey string)
+	// Deletes a range of keys, lexicographically between `lowKey` (included) and `highKey` (excluded)
+	DeleteRange(ord uint64, lowKey, highKey string)
 	//// Deletes a range of keys, first considering the _value_ of such keys as a _pointerSeparator_-separated list of keys to _also_ delete.
 	//DeleteRangePointers(lowKey, highKey, pointerSeparator string)
 }
@@ -114,6 +143,10 @@ type MaxBigFloatSetter interface {
 	SetMaxBigFloat(ord uint64, key string, value *big.Float)
 }
 
+type MaxBigDecimalSetter interface {
+	SetMaxBigDecimal(ord uint64, key string, value *big.Rat)
+}
+
 type MinBigIntSetter interface {
 	SetMinBigInt(ord uint64, key string, value *big.Int)
 }
@@ -127,6 +160,10 @@ type MinBigFloatSetter interface {
 	SetMinBigFloat(ord uint64, key string, value *big.Float)
 }
 
+type MinBigDecimalSetter interface {
+	SetMinBigDecimal(ord uint64, key string, value *big.Rat)
+}
+
 type SumBigIntSetter interface {
 	SumBigInt(ord uint64, key string, value *big.Int)
 }
@@ -139,3 +176,6 @@ type SumFloat64Setter interface {
 type SumBigFloatSetter interface {
 	SumBigFloat(ord uint64, key string, value *big.Float)
 }
+type SumBigDecimalSetter interface {
+	SumBigDecimal(ord uint64, key string, value *big.Rat)
+}
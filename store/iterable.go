@@ -1,14 +1,22 @@
 package store
 
 func (s *BaseStore) Length() uint64 {
-	return uint64(len(s.kv))
+	return s.kv.len()
 }
 
+// Iter calls `f` for each key of the store, in lexical order.
 func (s *BaseStore) Iter(f func(key string, value []byte) error) error {
-	for k, v := range s.kv {
-		if err := f(k, v); err != nil {
-			return err
-		}
-	}
-	return nil
+	return s.kv.iterRange("", "", f)
+}
+
+// IterRange calls `f` for the keys between `low` (included) and `high`
+// (excluded), in lexical order, without upper bound when `high` is empty.
+// Only the keys in the range are visited.
+func (s *BaseStore) IterRange(low, high string, f func(key string, value []byte) error) error {
+	return s.kv.iterRange(low, high, f)
+}
+
+// IterPrefix calls `f` for the keys starting with `prefix`, in lexical order.
+func (s *BaseStore) IterPrefix(prefix string, f func(key string, value []byte) error) error {
+	return s.kv.iterRange(prefix, prefixEnd(prefix), f)
 }
@@ -0,0 +1,119 @@
+package store
+
+import (
+	"sort"
+)
+
+// kvStore holds the keys and values of a store, in memory (`memoryKV`) or
+// in an on-disk KV engine (`diskKV`). All the semantics of the stores, like
+// deltas and merges, are implemented over it, so both behave the same.
+type kvStore interface {
+	get(key string) ([]byte, bool)
+	set(key string, value []byte)
+	delete(key string)
+
+	len() uint64
+	// size is the total length of the keys and values.
+	size() uint64
+
+	// iter calls `f` for each key, in no particular order.
+	iter(f func(key string, value []byte) error) error
+	// iterRange calls `f` for each key between `low` (included) and `high`
+	// (excluded), in lexical order, without upper bound when `high` is empty.
+	// `f` must not modify the keys.
+	iterRange(low, high string, f func(key string, value []byte) error) error
+
+	// err returns the first failure of writing to the kv, after which its
+	// keys are not reliable anymore.
+	err() error
+	close() error
+}
+
+// memoryKV holds its keys in a map only, writes staying O(1): the keys of a
+// range are sorted when it is iterated, the matched ones only.
+type memoryKV struct {
+	kv    map[string][]byte
+	bytes uint64
+}
+
+func newMemoryKV(kv map[string][]byte) *memoryKV {
+	if kv == nil {
+		kv = map[string][]byte{}
+	}
+	m := &memoryKV{kv: kv}
+	for key, value := range kv {
+		m.bytes += uint64(len(key) + len(value))
+	}
+	return m
+}
+
+func (m *memoryKV) get(key string) ([]byte, bool) {
+	value, found := m.kv[key]
+	return value, found
+}
+
+func (m *memoryKV) set(key string, value []byte) {
+	if prev, found := m.kv[key]; found {
+		m.bytes -= uint64(len(key) + len(prev))
+	}
+	m.kv[key] = value
+	m.bytes += uint64(len(key) + len(value))
+}
+
+func (m *memoryKV) delete(key string) {
+	if prev, found := m.kv[key]; found {
+		m.bytes -= uint64(len(key) + len(prev))
+		delete(m.kv, key)
+	}
+}
+
+func (m *memoryKV) len() uint64  { return uint64(len(m.kv)) }
+func (m *memoryKV) size() uint64 { return m.bytes }
+
+func (m *memoryKV) iter(f func(key string, value []byte) error) error {
+	for key, value := range m.kv {
+		if err := f(key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *memoryKV) iterRange(low, high string, f func(key string, value []byte) error) error {
+	// collected first, `f` can add or delete keys
+	var keys []string
+	for key := range m.kv {
+		if key >= low && (high == "" || key < high) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value, found := m.kv[key]
+		if !found {
+			continue
+		}
+		if err := f(key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *memoryKV) err() error   { return nil }
+func (m *memoryKV) close() error { return nil }
+
+// prefixEnd returns the lowest key greater than all the keys starting with
+// `prefix`, to iterate them with `iterRange`. It is empty, no upper bound,
+// when `prefix` is empty or made of 0xFF bytes only.
+func prefixEnd(prefix string) string {
+	end := []byte(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] != 0xFF {
+			end[i]++
+			return string(end[:i+1])
+		}
+	}
+	return ""
+}
@@ -0,0 +1,168 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/streamingfast/dstore"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKV_iterRange(t *testing.T) {
+	forEachKVBackend(t, func(t *testing.T) {
+		kv := newTestKV(nil)
+		for _, key := range []string{"b", "a:2", "a:1", "a", "c", "a:10", "a\xff"} {
+			kv.set(key, []byte(key))
+		}
+
+		keys := func(low, high string) (out []string) {
+			require.NoError(t, kv.iterRange(low, high, func(key string, value []byte) error {
+				assert.Equal(t, key, string(value))
+				out = append(out, key)
+				return nil
+			}))
+			return out
+		}
+
+		assert.Equal(t, []string{"a", "a:1", "a:10", "a:2", "a\xff", "b", "c"}, keys("", ""))
+		assert.Equal(t, []string{"a:1", "a:10", "a:2"}, keys("a:", prefixEnd("a:")))
+		assert.Equal(t, []string{"a:10", "a:2", "a\xff"}, keys("a:10", "b"))
+		assert.Equal(t, []string{"b", "c"}, keys("a\xff\x00", ""))
+		assert.Nil(t, keys("d", ""))
+
+		// the order follows the keys added and deleted
+		kv.set("a:0", []byte("a:0"))
+		kv.delete("a:10")
+		kv.set("a:2", []byte("a:2"))
+		assert.Equal(t, []string{"a:0", "a:1", "a:2"}, keys("a:", prefixEnd("a:")))
+	})
+}
+
+func TestKV_emptyAndLongKeys(t *testing.T) {
+	forEachKVBackend(t, func(t *testing.T) {
+		// longer than bbolt allows
+		long := strings.Repeat("a", 40*1024)
+		kv := newTestKV(nil)
+		for _, key := range []string{"b", long + "y", "", long + "x", "a"} {
+			kv.set(key, []byte("v:"+key))
+		}
+		kv.set(long+"x", []byte("v:"+long+"x"))
+		require.NoError(t, kv.err())
+		assert.Equal(t, uint64(5), kv.len())
+
+		for _, key := range []string{"", long + "x"} {
+			value, found := kv.get(key)
+			require.True(t, found)
+			assert.Equal(t, []byte("v:"+key), value)
+		}
+
+		keys := func(low, high string) (out []string) {
+			require.NoError(t, kv.iterRange(low, high, func(key string, value []byte) error {
+				assert.Equal(t, "v:"+key, string(value))
+				out = append(out, key)
+				return nil
+			}))
+			return out
+		}
+		assert.Equal(t, []string{"", "a", long + "x", long + "y", "b"}, keys("", ""))
+		assert.Equal(t, []string{long + "y"}, keys(long+"y", "b"))
+
+		sizeBefore := kv.size()
+		kv.delete(long + "x")
+		kv.delete("")
+		assert.Equal(t, uint64(3), kv.len())
+		assert.Equal(t, sizeBefore-uint64(2*len(long+"x")+4), kv.size())
+		_, found := kv.get(long + "x")
+		assert.False(t, found)
+		assert.Equal(t, []string{"a", long + "y", "b"}, keys("", ""))
+	})
+}
+
+func TestPrefixEnd(t *testing.T) {
+	assert.Equal(t, "", prefixEnd(""))
+	assert.Equal(t, "b", prefixEnd("a"))
+	assert.Equal(t, "a;", prefixEnd("a:"))
+	assert.Equal(t, "b", prefixEnd("a\xff"))
+	assert.Equal(t, "", prefixEnd("\xff\xff"))
+}
+
+func TestBaseStore_Iter(t *testing.T) {
+	forEachKVBackend(t, func(t *testing.T) {
+		s := NewTestKVStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", nil)
+		useTestBackend(t, s.BaseStore)
+		for i := 99; i >= 0; i-- {
+			s.Set(0, fmt.Sprintf("k:%02d", i), "v")
+		}
+
+		var keys []string
+		require.NoError(t, s.Iter(func(key string, _ []byte) error {
+			keys = append(keys, key)
+			return nil
+		}))
+		require.Len(t, keys, 100)
+		assert.Equal(t, "k:00", keys[0])
+		assert.Equal(t, "k:99", keys[99])
+
+		keys = nil
+		require.NoError(t, s.IterRange("k:10", "k:13", func(key string, _ []byte) error {
+			keys = append(keys, key)
+			return nil
+		}))
+		assert.Equal(t, []string{"k:10", "k:11", "k:12"}, keys)
+
+		keys = nil
+		require.NoError(t, s.IterPrefix("k:9", func(key string, _ []byte) error {
+			keys = append(keys, key)
+			return nil
+		}))
+		assert.Len(t, keys, 10)
+		assert.Equal(t, "k:90", keys[0])
+	})
+}
+
+func TestFullKV_writeAfterSave(t *testing.T) {
+	forEachKVBackend(t, func(t *testing.T) {
+		ctx := context.Background()
+		s := NewTestKVStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", newTestFileStore(t))
+		useTestBackend(t, s.BaseStore)
+		s.Set(0, "b", "1")
+		s.Set(1, "d", "1")
+		_, err := s.Save(ctx, 10)
+		require.NoError(t, err)
+
+		s.Set(2, "a", "2")
+		s.Set(3, "c", "2")
+		s.Del(4, "d")
+
+		var keys []string
+		require.NoError(t, s.Iter(func(key string, _ []byte) error {
+			keys = append(keys, key)
+			return nil
+		}))
+		assert.Equal(t, []string{"a", "b", "c"}, keys)
+	})
+}
+
+func BenchmarkFullKV_setAfterSave(b *testing.B) {
+	ctx := context.Background()
+	fileStore, err := dstore.NewStore("file://"+b.TempDir(), "", "", false)
+	require.NoError(b, err)
+
+	s := NewTestKVStore(b, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", fileStore)
+	for i := 0; i < 100_000; i++ {
+		s.Set(0, fmt.Sprintf("key:%08d", i*2), "v")
+		s.Reset()
+	}
+	_, err = s.Save(ctx, 10)
+	require.NoError(b, err)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Set(0, fmt.Sprintf("key:%08d", i*2+1), "v")
+		s.Reset() // deltas of a block
+	}
+}
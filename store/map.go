@@ -2,6 +2,7 @@ package store
 
 import (
 	"errors"
+	"fmt"
 	"go.uber.org/zap/zapcore"
 )
 
@@ -39,6 +40,16 @@ func (m *Map) All() map[string]Store {
 	return m.stores
 }
 
+// Close closes all the stores, returning the first error.
+func (m *Map) Close() (err error) {
+	for _, s := range m.stores {
+		if closeErr := s.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("closing store %q: %w", s.Name(), closeErr)
+		}
+	}
+	return err
+}
+
 func (m *Map) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	enc.AddInt("count", len(m.stores))
 	return nil
@@ -11,11 +11,12 @@ import (
 )
 
 const (
-	OutputValueTypeInt64    = "int64"
-	OutputValueTypeFloat64  = "float64"
-	OutputValueTypeBigInt   = "bigint"
-	OutputValueTypeBigFloat = "bigfloat"
-	OutputValueTypeString   = "string"
+	OutputValueTypeInt64      = "int64"
+	OutputValueTypeFloat64    = "float64"
+	OutputValueTypeBigInt     = "bigint"
+	OutputValueTypeBigFloat   = "bigfloat"
+	OutputValueTypeBigDecimal = "bigdecimal"
+	OutputValueTypeString     = "string"
 )
 
 // Merge nextStore _into_ `s`, where nextStore is for the next contiguous segment's store output.
@@ -30,34 +31,62 @@ func (s *BaseStore) Merge(kvPartialStore *PartialKV) error {
 		return fmt.Errorf("incompatible value types: cannot merge %q and %q", s.valueType, kvPartialStore.valueType)
 	}
 
+	if err := s.Err(); err != nil {
+		return err
+	}
+	if err := kvPartialStore.Err(); err != nil {
+		return err
+	}
+
+	if !s.skipValueValidation {
+		err := kvPartialStore.kv.iter(func(k string, v []byte) error {
+			if err := ValidateValue(s.valueType, v); err != nil {
+				return fmt.Errorf("key %q: %w", k, err)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
 	for _, prefix := range kvPartialStore.DeletedPrefixes {
 		s.DeletePrefix(kvPartialStore.lastOrdinal, prefix)
 	}
+	for _, key := range kvPartialStore.DeletedKeys {
+		s.Del(kvPartialStore.lastOrdinal, key)
+	}
+	for _, keyRange := range kvPartialStore.DeletedRanges {
+		s.DeleteRange(kvPartialStore.lastOrdinal, keyRange.Low, keyRange.High)
+	}
 
 	intoValueTypeLower := strings.ToLower(s.valueType)
 
 	switch s.updatePolicy {
 	case pbsubstreams.Module_KindStore_UPDATE_POLICY_SET:
-		for k, v := range kvPartialStore.kv {
-			s.kv[k] = v
-		}
+		kvPartialStore.kv.iter(func(k string, v []byte) error {
+			s.setKV(k, v)
+			return nil
+		})
 	case pbsubstreams.Module_KindStore_UPDATE_POLICY_SET_IF_NOT_EXISTS:
-		for k, v := range kvPartialStore.kv {
-			if _, found := s.kv[k]; !found {
-				s.kv[k] = v
+		kvPartialStore.kv.iter(func(k string, v []byte) error {
+			if _, found := s.kv.get(k); !found {
+				s.setKV(k, v)
 			}
-		}
+			return nil
+		})
 	case pbsubstreams.Module_KindStore_UPDATE_POLICY_APPEND:
-		for key, nextVal := range kvPartialStore.kv {
-			if prevVal, found := s.kv[key]; found {
+		kvPartialStore.kv.iter(func(key string, nextVal []byte) error {
+			if prevVal, found := s.kv.get(key); found {
 				newVal := make([]byte, len(prevVal)+len(nextVal))
 				copy(newVal[0:], prevVal)
 				copy(newVal[len(prevVal):], nextVal)
-				s.kv[key] = newVal
+				s.setKV(key, newVal)
 			} else {
-				s.kv[key] = nextVal
+				s.setKV(key, nextVal)
 			}
-		}
+			return nil
+		})
 	case pbsubstreams.Module_KindStore_UPDATE_POLICY_ADD:
 		// check valueType to do the right thing
 		switch intoValueTypeLower {
@@ -65,42 +94,54 @@ func (s *BaseStore) Merge(kvPartialStore *PartialKV) error {
 			sum := func(a, b uint64) uint64 {
 				return a + b
 			}
-			for k, v := range kvPartialStore.kv {
-				v0b, fv0 := s.kv[k]
+			kvPartialStore.kv.iter(func(k string, v []byte) error {
+				v0b, fv0 := s.kv.get(k)
 				v0 := foundOrZeroUint64(v0b, fv0)
 				v1 := foundOrZeroUint64(v, true)
-				s.kv[k] = []byte(fmt.Sprintf("%d", sum(v0, v1)))
-			}
+				s.setKV(k, []byte(fmt.Sprintf("%d", sum(v0, v1))))
+				return nil
+			})
 		case OutputValueTypeFloat64:
 			sum := func(a, b float64) float64 {
 				return a + b
 			}
-			for k, v := range kvPartialStore.kv {
-				v0b, fv0 := s.kv[k]
+			kvPartialStore.kv.iter(func(k string, v []byte) error {
+				v0b, fv0 := s.kv.get(k)
 				v0 := foundOrZeroFloat(v0b, fv0)
 				v1 := foundOrZeroFloat(v, true)
-				s.kv[k] = []byte(floatToStr(sum(v0, v1)))
-			}
+				s.setKV(k, []byte(floatToStr(sum(v0, v1))))
+				return nil
+			})
 		case OutputValueTypeBigInt:
 			sum := func(a, b *big.Int) *big.Int {
 				return bi().Add(a, b)
 			}
-			for k, v := range kvPartialStore.kv {
-				v0b, fv0 := s.kv[k]
+			kvPartialStore.kv.iter(func(k string, v []byte) error {
+				v0b, fv0 := s.kv.get(k)
 				v0 := foundOrZeroBigInt(v0b, fv0)
 				v1 := foundOrZeroBigInt(v, true)
-				s.kv[k] = []byte(fmt.Sprintf("%d", sum(v0, v1)))
-			}
+				s.setKV(k, []byte(fmt.Sprintf("%d", sum(v0, v1))))
+				return nil
+			})
 		case OutputValueTypeBigFloat:
 			sum := func(a, b *big.Float) *big.Float {
 				return bf().Add(a, b).SetPrec(100)
 			}
-			for k, v := range kvPartialStore.kv {
-				v0b, fv0 := s.kv[k]
+			kvPartialStore.kv.iter(func(k string, v []byte) error {
+				v0b, fv0 := s.kv.get(k)
 				v0 := foundOrZeroBigFloat(v0b, fv0)
 				v1 := foundOrZeroBigFloat(v, true)
-				s.kv[k] = []byte(bigFloatToStr(sum(v0, v1)))
-			}
+				s.setKV(k, []byte(bigFloatToStr(sum(v0, v1))))
+				return nil
+			})
+		case OutputValueTypeBigDecimal:
+			kvPartialStore.kv.iter(func(k string, v []byte) error {
+				v0b, fv0 := s.kv.get(k)
+				v0 := foundOrZeroBigDecimal(v0b, fv0)
+				v1 := foundOrZeroBigDecimal(v, true)
+				s.setKV(k, []byte(BigDecimalToString(new(big.Rat).Add(v0, v1))))
+				return nil
+			})
 		default:
 			return fmt.Errorf("update policy %q not supported for value type %s", s.updatePolicy, s.valueType)
 		}
@@ -113,17 +154,18 @@ func (s *BaseStore) Merge(kvPartialStore *PartialKV) error {
 				}
 				return b
 			}
-			for k, v := range kvPartialStore.kv {
+			kvPartialStore.kv.iter(func(k string, v []byte) error {
 				v1 := foundOrZeroUint64(v, true)
-				v, found := s.kv[k]
+				v, found := s.kv.get(k)
 				if !found {
-					s.kv[k] = []byte(fmt.Sprintf("%d", v1))
-					continue
+					s.setKV(k, []byte(fmt.Sprintf("%d", v1)))
+					return nil
 				}
 				v0 := foundOrZeroUint64(v, true)
 
-				s.kv[k] = []byte(fmt.Sprintf("%d", max(v0, v1)))
-			}
+				s.setKV(k, []byte(fmt.Sprintf("%d", max(v0, v1))))
+				return nil
+			})
 		case OutputValueTypeFloat64:
 			max := func(a, b float64) float64 {
 				if a < b {
@@ -131,17 +173,18 @@ func (s *BaseStore) Merge(kvPartialStore *PartialKV) error {
 				}
 				return a
 			}
-			for k, v := range kvPartialStore.kv {
+			kvPartialStore.kv.iter(func(k string, v []byte) error {
 				v1 := foundOrZeroFloat(v, true)
-				v, found := s.kv[k]
+				v, found := s.kv.get(k)
 				if !found {
-					s.kv[k] = []byte(floatToStr(v1))
-					continue
+					s.setKV(k, []byte(floatToStr(v1)))
+					return nil
 				}
 				v0 := foundOrZeroFloat(v, true)
 
-				s.kv[k] = []byte(floatToStr(max(v0, v1)))
-			}
+				s.setKV(k, []byte(floatToStr(max(v0, v1))))
+				return nil
+			})
 		case OutputValueTypeBigInt:
 			max := func(a, b *big.Int) *big.Int {
 				if a.Cmp(b) <= 0 {
@@ -149,17 +192,18 @@ func (s *BaseStore) Merge(kvPartialStore *PartialKV) error {
 				}
 				return a
 			}
-			for k, v := range kvPartialStore.kv {
+			kvPartialStore.kv.iter(func(k string, v []byte) error {
 				v1 := foundOrZeroBigInt(v, true)
-				v, found := s.kv[k]
+				v, found := s.kv.get(k)
 				if !found {
-					s.kv[k] = []byte(v1.String())
-					continue
+					s.setKV(k, []byte(v1.String()))
+					return nil
 				}
 				v0 := foundOrZeroBigInt(v, true)
 
-				s.kv[k] = []byte(fmt.Sprintf("%d", max(v0, v1)))
-			}
+				s.setKV(k, []byte(fmt.Sprintf("%d", max(v0, v1))))
+				return nil
+			})
 		case OutputValueTypeBigFloat:
 			max := func(a, b *big.Float) *big.Float {
 				if a.Cmp(b) <= 0 {
@@ -167,17 +211,28 @@ func (s *BaseStore) Merge(kvPartialStore *PartialKV) error {
 				}
 				return a
 			}
-			for k, v := range kvPartialStore.kv {
+			kvPartialStore.kv.iter(func(k string, v []byte) error {
 				v1 := foundOrZeroBigFloat(v, true)
-				v, found := s.kv[k]
+				v, found := s.kv.get(k)
 				if !found {
-					s.kv[k] = []byte(bigFloatToStr(v1))
-					continue
+					s.setKV(k, []byte(bigFloatToStr(v1)))
+					return nil
 				}
 				v0 := foundOrZeroBigFloat(v, true)
 
-				s.kv[k] = []byte(bigFloatToStr(max(v0, v1)))
-			}
+				s.setKV(k, []byte(bigFloatToStr(max(v0, v1))))
+				return nil
+			})
+		case OutputValueTypeBigDecimal:
+			kvPartialStore.kv.iter(func(k string, v []byte) error {
+				v1 := foundOrZeroBigDecimal(v, true)
+				v, found := s.kv.get(k)
+				if found && foundOrZeroBigDecimal(v, true).Cmp(v1) >= 0 {
+					return nil
+				}
+				s.setKV(k, []byte(BigDecimalToString(v1)))
+				return nil
+			})
 		default:
 			return fmt.Errorf("update policy %q not supported for value type %s", kvPartialStore.updatePolicy, kvPartialStore.valueType)
 		}
@@ -190,17 +245,18 @@ func (s *BaseStore) Merge(kvPartialStore *PartialKV) error {
 				}
 				return b
 			}
-			for k, v := range kvPartialStore.kv {
+			kvPartialStore.kv.iter(func(k string, v []byte) error {
 				v1 := foundOrZeroUint64(v, true)
-				v, found := s.kv[k]
+				v, found := s.kv.get(k)
 				if !found {
-					s.kv[k] = []byte(fmt.Sprintf("%d", v1))
-					continue
+					s.setKV(k, []byte(fmt.Sprintf("%d", v1)))
+					return nil
 				}
 				v0 := foundOrZeroUint64(v, true)
 
-				s.kv[k] = []byte(fmt.Sprintf("%d", min(v0, v1)))
-			}
+				s.setKV(k, []byte(fmt.Sprintf("%d", min(v0, v1))))
+				return nil
+			})
 		case OutputValueTypeFloat64:
 			min := func(a, b float64) float64 {
 				if a < b {
@@ -208,17 +264,18 @@ func (s *BaseStore) Merge(kvPartialStore *PartialKV) error {
 				}
 				return b
 			}
-			for k, v := range kvPartialStore.kv {
+			kvPartialStore.kv.iter(func(k string, v []byte) error {
 				v1 := foundOrZeroFloat(v, true)
-				v, found := s.kv[k]
+				v, found := s.kv.get(k)
 				if !found {
-					s.kv[k] = []byte(floatToStr(v1))
-					continue
+					s.setKV(k, []byte(floatToStr(v1)))
+					return nil
 				}
 				v0 := foundOrZeroFloat(v, true)
 
-				s.kv[k] = []byte(floatToStr(min(v0, v1)))
-			}
+				s.setKV(k, []byte(floatToStr(min(v0, v1))))
+				return nil
+			})
 		case OutputValueTypeBigInt:
 			min := func(a, b *big.Int) *big.Int {
 				if a.Cmp(b) <= 0 {
@@ -226,17 +283,18 @@ func (s *BaseStore) Merge(kvPartialStore *PartialKV) error {
 				}
 				return b
 			}
-			for k, v := range kvPartialStore.kv {
+			kvPartialStore.kv.iter(func(k string, v []byte) error {
 				v1 := foundOrZeroBigInt(v, true)
-				v, found := s.kv[k]
+				v, found := s.kv.get(k)
 				if !found {
-					s.kv[k] = []byte(v1.String())
-					continue
+					s.setKV(k, []byte(v1.String()))
+					return nil
 				}
 				v0 := foundOrZeroBigInt(v, true)
 
-				s.kv[k] = []byte(fmt.Sprintf("%d", min(v0, v1)))
-			}
+				s.setKV(k, []byte(fmt.Sprintf("%d", min(v0, v1))))
+				return nil
+			})
 		case OutputValueTypeBigFloat:
 			min := func(a, b *big.Float) *big.Float {
 				if a.Cmp(b) <= 0 {
@@ -244,17 +302,28 @@ func (s *BaseStore) Merge(kvPartialStore *PartialKV) error {
 				}
 				return b
 			}
-			for k, v := range kvPartialStore.kv {
+			kvPartialStore.kv.iter(func(k string, v []byte) error {
 				v1 := foundOrZeroBigFloat(v, true)
-				v, found := s.kv[k]
+				v, found := s.kv.get(k)
 				if !found {
-					s.kv[k] = []byte(bigFloatToStr(v1))
-					continue
+					s.setKV(k, []byte(bigFloatToStr(v1)))
+					return nil
 				}
 				v0 := foundOrZeroBigFloat(v, true)
 
-				s.kv[k] = []byte(bigFloatToStr(min(v0, v1)))
-			}
+				s.setKV(k, []byte(bigFloatToStr(min(v0, v1))))
+				return nil
+			})
+		case OutputValueTypeBigDecimal:
+			kvPartialStore.kv.iter(func(k string, v []byte) error {
+				v1 := foundOrZeroBigDecimal(v, true)
+				v, found := s.kv.get(k)
+				if found && foundOrZeroBigDecimal(v, true).Cmp(v1) <= 0 {
+					return nil
+				}
+				s.setKV(k, []byte(BigDecimalToString(v1)))
+				return nil
+			})
 		default:
 			return fmt.Errorf("update policy %q not supported for value type %s", s.updatePolicy, s.valueType)
 		}
@@ -262,7 +331,7 @@ func (s *BaseStore) Merge(kvPartialStore *PartialKV) error {
 		return fmt.Errorf("update policy %q not supported", s.updatePolicy) // should have been validated already
 	}
 
-	return nil
+	return s.Err()
 }
 
 func foundOrZeroUint64(in []byte, found bool) uint64 {
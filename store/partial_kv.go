@@ -2,9 +2,10 @@ package store
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"io"
 	"github.com/streamingfast/substreams/block"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
 	"go.uber.org/zap"
 )
 
@@ -16,6 +17,8 @@ type PartialKV struct {
 
 	initialBlock    uint64 // block at which we initialized this store
 	DeletedPrefixes []string
+	DeletedKeys     []string
+	DeletedRanges   []*KeyRange
 }
 
 func NewPartialKV(store *BaseStore, initialBlock uint64) *PartialKV {
@@ -27,7 +30,10 @@ func NewPartialKV(store *BaseStore, initialBlock uint64) *PartialKV {
 
 func (p *PartialKV) Roll(lastBlock uint64) {
 	p.initialBlock = lastBlock
-	p.BaseStore.kv = map[string][]byte{}
+	p.replaceKV(newMemoryKV(nil)) // moved to disk on the first write when needed
+	p.DeletedPrefixes = nil
+	p.DeletedKeys = nil
+	p.DeletedRanges = nil
 }
 
 func (s *PartialKV) InitialBlock() uint64 { return s.initialBlock }
@@ -39,23 +45,29 @@ func (p *PartialKV) storageFilename(exclusiveEndBlock uint64) string {
 type storeData struct {
 	KV              map[string][]byte `json:"kv"`
 	DeletedPrefixes []string          `json:"deleted_prefixes"`
+	DeletedKeys     []string          `json:"deleted_keys,omitempty"`
+	DeletedRanges   []*KeyRange       `json:"deleted_ranges,omitempty"`
 }
 
 func (p *PartialKV) Load(ctx context.Context, exclusiveEndBlock uint64) error {
 	filename := p.storageFilename(exclusiveEndBlock)
 	p.logger.Debug("loading partial store state from file", zap.String("filename", filename))
 
-	data, err := loadStore(ctx, p.store, filename)
+	var stateData *storeData
+	kv, err := p.loadKV(ctx, filename, func(r io.Reader, put func(key string, value []byte)) (err error) {
+		stateData, err = p.readPartialSnapshot(r, put)
+		return err
+	})
 	if err != nil {
 		return fmt.Errorf("load partial store %s at %s: %w", p.name, filename, err)
 	}
-
-	stateData := &storeData{}
-	if err = json.Unmarshal(data, &stateData); err != nil {
-		return fmt.Errorf("unmarshal data: %w", err)
+	p.replaceKV(kv)
+	if err := p.Err(); err != nil {
+		return fmt.Errorf("load partial store %s at %s: %w", p.name, filename, err)
 	}
-	p.kv = stateData.KV
 	p.DeletedPrefixes = stateData.DeletedPrefixes
+	p.DeletedKeys = stateData.DeletedKeys
+	p.DeletedRanges = stateData.DeletedRanges
 
 	p.logger.Debug("partial store loaded", zap.String("filename", filename))
 	return nil
@@ -63,19 +75,22 @@ func (p *PartialKV) Load(ctx context.Context, exclusiveEndBlock uint64) error {
 
 func (p *PartialKV) Save(ctx context.Context, endBoundaryBlock uint64) (*block.Range, error) {
 	p.logger.Debug("writing partial store  state", zap.Object("store", p))
-
-	data := &storeData{
-		KV:              p.kv,
-		DeletedPrefixes: p.DeletedPrefixes,
+	if err := p.Err(); err != nil {
+		return nil, err
 	}
 
-	content, err := json.MarshalIndent(data, "", "  ")
-	if err != nil {
-		return nil, fmt.Errorf("marshal partial data: %w", err)
+	header := p.snapshotHeader(true)
+	header.DeletedPrefixes = p.DeletedPrefixes
+	header.DeletedKeys = p.DeletedKeys
+	for _, r := range p.DeletedRanges {
+		header.DeletedRanges = append(header.DeletedRanges, &pbsubstreams.StoreKeyRange{Low: r.Low, High: r.High})
 	}
 
 	filename := p.storageFilename(endBoundaryBlock)
-	if err := saveStore(ctx, p.store, filename, content); err != nil {
+	err := saveStore(ctx, p.store, filename, p.compression, func(w io.Writer) error {
+		return writeSnapshot(w, header, p.kv)
+	})
+	if err != nil {
 		return nil, fmt.Errorf("write partial store %q in file %q: %w", p.name, filename, err)
 	}
 
@@ -95,6 +110,18 @@ func (p *PartialKV) DeletePrefix(ord uint64, prefix string) {
 	p.DeletedPrefixes = append(p.DeletedPrefixes, prefix)
 }
 
+func (p *PartialKV) Del(ord uint64, key string) {
+	p.BaseStore.Del(ord, key)
+
+	p.DeletedKeys = append(p.DeletedKeys, key)
+}
+
+func (p *PartialKV) DeleteRange(ord uint64, lowKey, highKey string) {
+	p.BaseStore.DeleteRange(ord, lowKey, highKey)
+
+	p.DeletedRanges = append(p.DeletedRanges, &KeyRange{Low: lowKey, High: highKey})
+}
+
 func (p *PartialKV) DeleteStore(ctx context.Context, endBlock uint64) (err error) {
 	filename := p.storageFilename(endBlock)
 	zlog.Debug("deleting full store file", zap.String("file_name", filename))
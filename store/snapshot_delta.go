@@ -0,0 +1,198 @@
+package store
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/streamingfast/substreams/block"
+	"go.uber.org/zap"
+)
+
+// DefaultCheckpointInterval is the number of snapshots of full stores per
+// full checkpoint used by the service, the others being delta segments.
+const DefaultCheckpointInterval = 10
+
+// snapshotChanges tracks the keys of a full store changed since its snapshot
+// ending at `block`, to be written as the next delta segment.
+type snapshotChanges struct {
+	block uint64
+	depth uint64 // delta segments since the full checkpoint
+	keys  map[string]struct{}
+}
+
+func (c *snapshotChanges) add(key string) {
+	if c != nil {
+		c.keys[key] = struct{}{}
+	}
+}
+
+// SetCheckpointInterval makes full stores save a full checkpoint every
+// `interval` snapshots, the snapshots in between being delta segments, the
+// `.delta` files, holding only the keys changed since the previous snapshot.
+// `Load` reconstructs the state from the nearest checkpoint and the deltas
+// following it. With an interval of 0 or 1, every snapshot is a checkpoint.
+func (s *BaseStore) SetCheckpointInterval(interval uint64) {
+	s.checkpointInterval = interval
+}
+
+// trackChanges starts tracking the changes following the snapshot ending at
+// `block`, when delta segments are enabled.
+func (s *BaseStore) trackChanges(block, depth uint64) {
+	s.changes = nil
+	if s.checkpointInterval > 1 {
+		s.changes = &snapshotChanges{block: block, depth: depth, keys: map[string]struct{}{}}
+	}
+}
+
+// nextSnapshotIsDelta tells if the snapshot ending at `endBlock` is to be a
+// delta segment on top of the last one.
+func (s *BaseStore) nextSnapshotIsDelta(endBlock uint64) bool {
+	return s.changes != nil && endBlock > s.changes.block && s.changes.depth+1 < s.checkpointInterval
+}
+
+// saveDelta writes the keys changed since the last snapshot as the delta
+// segment ending at `endBlock`.
+func (s *BaseStore) saveDelta(ctx context.Context, endBlock uint64) (string, error) {
+	changes := s.changes
+	s.changes = &snapshotChanges{block: endBlock, depth: changes.depth + 1, keys: map[string]struct{}{}}
+
+	keys := make([]string, 0, len(changes.keys))
+	for key := range changes.keys {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	header := s.snapshotHeader(false)
+	header.Delta = true
+	for _, key := range keys {
+		if _, found := s.kv.get(key); found {
+			header.KeyCount++
+		} else {
+			header.DeletedKeys = append(header.DeletedKeys, key)
+		}
+	}
+
+	filename := deltaFileName(block.NewRange(changes.block, endBlock))
+	err := saveStore(ctx, s.store, filename, s.compression, func(w io.Writer) error {
+		sw, err := newSnapshotWriter(w, header)
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			value, found := s.kv.get(key)
+			if !found {
+				continue
+			}
+			if err := sw.writeKeyValue(key, value); err != nil {
+				return fmt.Errorf("writing key %q: %w", key, err)
+			}
+		}
+		return sw.close()
+	})
+	if err != nil {
+		s.changes = changes
+		return "", err
+	}
+	return filename, nil
+}
+
+// applyDeltaSnapshot applies the delta segment `filename` to the state. The
+// deltas setting the last value of the keys, a retried read applies again
+// with the same result.
+func (s *BaseStore) applyDeltaSnapshot(ctx context.Context, filename string) error {
+	return loadStore(ctx, s.store, filename, func(r io.Reader) error {
+		sr, err := newSnapshotReader(bufio.NewReader(r))
+		if err != nil {
+			return corrupted(fmt.Errorf("unmarshal data: %w", err))
+		}
+		if err := s.checkSnapshotHeader(sr.header, false, true); err != nil {
+			return err
+		}
+		for _, key := range sr.header.DeletedKeys {
+			s.kv.delete(key)
+		}
+		if err := sr.readAll(s.setKV); err != nil {
+			return corrupted(fmt.Errorf("unmarshal data: %w", err))
+		}
+		return nil
+	})
+}
+
+// snapshotChain returns the snapshots to load for the state at `endBlock`:
+// the full checkpoint, followed by the delta segments applying on it, if any.
+func (s *BaseStore) snapshotChain(ctx context.Context, endBlock uint64) ([]*FileInfo, error) {
+	checkpoint := snapshotFileInfo(block.NewRange(s.moduleInitialBlock, endBlock), false)
+	exists, err := s.store.FileExists(ctx, checkpoint.Filename)
+	if err != nil {
+		return nil, fmt.Errorf("checking snapshot %q: %w", checkpoint.Filename, err)
+	}
+	if exists {
+		return []*FileInfo{checkpoint}, nil
+	}
+
+	files, err := s.ListSnapshotFiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	checkpoints := map[uint64]*FileInfo{}
+	deltas := map[uint64]*FileInfo{}
+	for _, file := range files {
+		switch {
+		case file.Delta:
+			deltas[file.EndBlock] = file
+		case !file.Partial && file.StartBlock == s.moduleInitialBlock:
+			checkpoints[file.EndBlock] = file
+		}
+	}
+
+	var chain []*FileInfo
+	for end := endBlock; ; {
+		if checkpoint := checkpoints[end]; checkpoint != nil {
+			return append([]*FileInfo{checkpoint}, chain...), nil
+		}
+		delta := deltas[end]
+		if delta == nil {
+			if end == endBlock {
+				return nil, fmt.Errorf("no snapshot ending at block %d", endBlock)
+			}
+			return nil, fmt.Errorf("no snapshot ending at block %d, on which %q applies", end, chain[0].Filename)
+		}
+		chain = append([]*FileInfo{delta}, chain...)
+		end = delta.StartBlock
+	}
+}
+
+// loadChain loads the state at `endBlock` from `snapshotChain`, returning the
+// number of delta segments applied.
+func (s *BaseStore) loadChain(ctx context.Context, endBlock uint64) (uint64, error) {
+	chain, err := s.snapshotChain(ctx, endBlock)
+	if err != nil {
+		return 0, err
+	}
+
+	s.changes = nil
+	kv, err := s.loadKV(ctx, chain[0].Filename, s.readFullSnapshot)
+	if err != nil {
+		if len(chain) > 1 {
+			return 0, fmt.Errorf("loading checkpoint %q: %w", chain[0].Filename, err)
+		}
+		return 0, err
+	}
+	s.replaceKV(kv)
+
+	for _, delta := range chain[1:] {
+		if err := s.applyDeltaSnapshot(ctx, delta.Filename); err != nil {
+			return 0, fmt.Errorf("applying delta %q: %w", delta.Filename, err)
+		}
+	}
+	if err := s.Err(); err != nil {
+		return 0, err
+	}
+	if len(chain) > 1 {
+		s.logger.Debug("applied delta segments", zap.String("checkpoint", chain[0].Filename), zap.Int("delta_count", len(chain)-1))
+	}
+	return uint64(len(chain) - 1), nil
+}
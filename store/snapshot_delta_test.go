@@ -0,0 +1,135 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/streamingfast/substreams/compress"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFullKV_deltaSnapshots(t *testing.T) {
+	forEachKVBackend(t, func(t *testing.T) {
+		ctx := context.Background()
+		fileStore := newTestFileStore(t)
+
+		s := NewTestKVStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", fileStore)
+		useTestBackend(t, s.BaseStore)
+		s.SetCheckpointInterval(3)
+
+		expected := map[uint64]map[string]string{}
+		save := func(endBlock uint64) {
+			_, err := s.Save(ctx, endBlock)
+			require.NoError(t, err)
+			expected[endBlock] = kvStrings(s.kv)
+			s.Reset()
+		}
+
+		s.Set(0, "a", "1")
+		s.Set(1, "b", "2")
+		save(100)
+		s.Set(0, "a", "10")
+		s.Del(1, "b")
+		s.Set(2, "c", "3")
+		save(200)
+		s.Set(0, "d", "4")
+		s.Set(1, "e", "5")
+		s.ApplyDeltasReverse(s.GetDeltas()[1:]) // e reverted
+		save(300)
+		s.Set(0, "a", "11")
+		save(400)
+		s.Del(0, "d")
+		save(500)
+
+		var filenames []string
+		files, err := s.ListSnapshotFiles(ctx)
+		require.NoError(t, err)
+		for _, file := range files {
+			filenames = append(filenames, file.Filename)
+		}
+		assert.ElementsMatch(t, []string{
+			"0000000100-0000000000.kv",
+			"0000000200-0000000100.delta",
+			"0000000300-0000000200.delta",
+			"0000000400-0000000000.kv",
+			"0000000500-0000000400.delta",
+		}, filenames)
+
+		assert.Equal(t, map[string]string{"a": "10", "c": "3", "d": "4"}, expected[300])
+		for endBlock, kv := range expected {
+			loaded := NewTestKVStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", fileStore)
+			useTestBackend(t, loaded.BaseStore)
+			require.NoError(t, loaded.Load(ctx, endBlock))
+			assert.Equal(t, kv, kvStrings(loaded.kv), "state at block %d", endBlock)
+		}
+
+		// loaded at the end of a delta segment, the chain goes on
+		loaded := s.Clone()
+		require.NoError(t, loaded.Load(ctx, 200))
+		loaded.Set(0, "f", "6")
+		_, err = loaded.Save(ctx, 250)
+		require.NoError(t, err)
+		require.NoError(t, loaded.Load(ctx, 250))
+		assert.Equal(t, map[string]string{"a": "10", "c": "3", "f": "6"}, kvStrings(loaded.kv))
+
+		require.NoError(t, s.store.DeleteObject(ctx, "0000000200-0000000100.delta"))
+		err = loaded.Load(ctx, 300)
+		assert.EqualError(t, err, `load full store test at 0000000300-0000000000.kv: no snapshot ending at block 200, on which "0000000300-0000000200.delta" applies`)
+	})
+}
+
+func TestFullKV_deleteCorruptedDelta(t *testing.T) {
+	ctx := context.Background()
+	fileStore := newTestFileStore(t)
+
+	s := NewTestKVStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", fileStore)
+	s.SetCheckpointInterval(3)
+	for _, endBlock := range []uint64{100, 200, 300, 400} {
+		s.Set(0, fmt.Sprintf("key%d", endBlock), "value")
+		_, err := s.Save(ctx, endBlock)
+		require.NoError(t, err)
+	}
+	loaded := s.Clone()
+	require.NoError(t, loaded.Load(ctx, 200))
+	loaded.Set(0, "other", "value")
+	_, err := loaded.Save(ctx, 250)
+	require.NoError(t, err)
+
+	data := mustLoad(t, s.store, "0000000200-0000000100.delta")
+	mustSave(t, s.store, "0000000200-0000000100.delta", data[:len(data)-3], compress.Default) // truncated upload
+
+	err = s.Clone().Load(ctx, 300)
+	require.True(t, errors.Is(err, ErrCorruptedSnapshot), err)
+	require.NoError(t, s.DeleteCorruptedSnapshot(ctx, err))
+
+	var filenames []string
+	files, err := s.ListSnapshotFiles(ctx)
+	require.NoError(t, err)
+	for _, file := range files {
+		filenames = append(filenames, file.Filename)
+	}
+	assert.ElementsMatch(t, []string{"0000000100-0000000000.kv", "0000000400-0000000000.kv"}, filenames)
+}
+
+func TestFullKV_deltaSnapshotsDisabled(t *testing.T) {
+	ctx := context.Background()
+	s := NewTestKVStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", newTestFileStore(t))
+	s.SetCheckpointInterval(1)
+
+	s.Set(0, "a", "1")
+	_, err := s.Save(ctx, 100)
+	require.NoError(t, err)
+	s.Set(0, "b", "2")
+	_, err = s.Save(ctx, 200)
+	require.NoError(t, err)
+
+	files, err := s.ListSnapshotFiles(ctx)
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	assert.False(t, files[0].Delta)
+	assert.False(t, files[1].Delta)
+}
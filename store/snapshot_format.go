@@ -0,0 +1,373 @@
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// Binary snapshots start with `snapshotMagic`, followed by length-prefixed
+// protobuf records: a `pbsubstreams.StoreSnapshotHeader`, then one
+// `pbsubstreams.StoreKeyValue` per key and a
+// `pbsubstreams.StoreSnapshotChecksum`. Snapshots not starting with the magic
+// are the legacy JSON ones, still loaded.
+var snapshotMagic = []byte("\x00sfstore")
+
+// snapshotVersion 2 added the checksum record, version 1 snapshots are still
+// loaded, unchecked.
+const (
+	snapshotVersion         = 2
+	snapshotVersionChecksum = 2
+)
+
+var crc32cTable = crc32.MakeTable(crc32.Castagnoli)
+
+// ErrCorruptedSnapshot is matched, with `errors.Is`, by the errors of loading
+// snapshots that are truncated, fail their checksum, or don't decode.
+var ErrCorruptedSnapshot = errors.New("corrupted snapshot")
+
+// corruptedSnapshotError marks `err` as matching `ErrCorruptedSnapshot`,
+// keeping its message. `loadStore` sets the file it failed to load.
+type corruptedSnapshotError struct {
+	err      error
+	filename string
+}
+
+func corrupted(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &corruptedSnapshotError{err: err}
+}
+
+func (e *corruptedSnapshotError) Error() string        { return e.err.Error() }
+func (e *corruptedSnapshotError) Unwrap() error        { return e.err }
+func (e *corruptedSnapshotError) Is(target error) bool { return target == ErrCorruptedSnapshot }
+
+// maxSnapshotRecordSize bounds the records of a snapshot, so reading fails
+// fast on corrupted lengths. Saving a store having a key and value larger
+// than that fails, rather than writing a snapshot that could not be loaded.
+var maxSnapshotRecordSize = 64 * 1024 * 1024
+
+const (
+	SnapshotFormatJSON   = "json"
+	SnapshotFormatBinary = "binary"
+)
+
+func isBinarySnapshot(data []byte) bool {
+	return bytes.HasPrefix(data, snapshotMagic)
+}
+
+func peekBinarySnapshot(r *bufio.Reader) bool {
+	magic, _ := r.Peek(len(snapshotMagic))
+	return isBinarySnapshot(magic)
+}
+
+type snapshotWriter struct {
+	w      *bufio.Writer
+	record []byte
+	crc    hash.Hash32
+}
+
+func newSnapshotWriter(w io.Writer, header *pbsubstreams.StoreSnapshotHeader) (*snapshotWriter, error) {
+	sw := &snapshotWriter{w: bufio.NewWriter(w), crc: crc32.New(crc32cTable)}
+	if _, err := sw.w.Write(snapshotMagic); err != nil {
+		return nil, err
+	}
+	sw.crc.Write(snapshotMagic)
+	header.Version = snapshotVersion
+	if err := sw.writeRecord(header); err != nil {
+		return nil, fmt.Errorf("writing header: %w", err)
+	}
+	return sw, nil
+}
+
+func (sw *snapshotWriter) writeRecord(msg proto.Message) (err error) {
+	sw.record, err = proto.MarshalOptions{}.MarshalAppend(sw.record[:0], msg)
+	if err != nil {
+		return err
+	}
+	if len(sw.record) > maxSnapshotRecordSize {
+		return fmt.Errorf("record of %d bytes exceeds the maximum snapshot record size of %d bytes", len(sw.record), maxSnapshotRecordSize)
+	}
+	var length [binary.MaxVarintLen64]byte
+	lengthBytes := length[:binary.PutUvarint(length[:], uint64(len(sw.record)))]
+	if _, err := sw.w.Write(lengthBytes); err != nil {
+		return err
+	}
+	if _, err = sw.w.Write(sw.record); err != nil {
+		return err
+	}
+	sw.crc.Write(lengthBytes)
+	sw.crc.Write(sw.record)
+	return nil
+}
+
+func (sw *snapshotWriter) writeKeyValue(key string, value []byte) error {
+	return sw.writeRecord(&pbsubstreams.StoreKeyValue{Key: key, Value: value})
+}
+
+// close writes the checksum of all the records written and flushes them.
+func (sw *snapshotWriter) close() error {
+	if err := sw.writeRecord(&pbsubstreams.StoreSnapshotChecksum{Crc32C: sw.crc.Sum32()}); err != nil {
+		return fmt.Errorf("writing checksum: %w", err)
+	}
+	return sw.w.Flush()
+}
+
+type snapshotReader struct {
+	r      *bufio.Reader
+	header *pbsubstreams.StoreSnapshotHeader
+	record []byte
+	crc    hash.Hash32
+	read   uint64 // keys
+}
+
+func newSnapshotReader(r io.Reader) (*snapshotReader, error) {
+	sr := &snapshotReader{r: bufio.NewReader(r), crc: crc32.New(crc32cTable)}
+
+	magic := make([]byte, len(snapshotMagic))
+	if _, err := io.ReadFull(sr.r, magic); err != nil || !bytes.Equal(magic, snapshotMagic) {
+		return nil, fmt.Errorf("not a binary snapshot")
+	}
+	sr.crc.Write(magic)
+
+	sr.header = &pbsubstreams.StoreSnapshotHeader{}
+	if err := sr.readRecord(sr.header); err != nil {
+		return nil, fmt.Errorf("reading header: %w", unexpectedEOF(err))
+	}
+	if sr.header.Version == 0 || sr.header.Version > snapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d", sr.header.Version)
+	}
+	return sr, nil
+}
+
+func (sr *snapshotReader) readRecord(msg proto.Message) error {
+	length, err := binary.ReadUvarint(sr.r)
+	if err != nil {
+		return err
+	}
+	if length > uint64(maxSnapshotRecordSize) {
+		return fmt.Errorf("record of %d bytes exceeds maximum size", length)
+	}
+	if uint64(cap(sr.record)) < length {
+		sr.record = make([]byte, length)
+	}
+	sr.record = sr.record[:length]
+	if _, err := io.ReadFull(sr.r, sr.record); err != nil {
+		return unexpectedEOF(err)
+	}
+	var lengthBytes [binary.MaxVarintLen64]byte
+	sr.crc.Write(lengthBytes[:binary.PutUvarint(lengthBytes[:], length)])
+	sr.crc.Write(sr.record)
+	return proto.Unmarshal(sr.record, msg)
+}
+
+// next returns the next key and value, or `io.EOF` once all the keys
+// announced by the header were read and the checksum verified.
+func (sr *snapshotReader) next() (string, []byte, error) {
+	if sr.header.Version >= snapshotVersionChecksum && sr.read == sr.header.KeyCount {
+		return "", nil, sr.verifyChecksum()
+	}
+
+	kv := &pbsubstreams.StoreKeyValue{}
+	if err := sr.readRecord(kv); err != nil {
+		if err == io.EOF && sr.header.Version >= snapshotVersionChecksum {
+			return "", nil, io.ErrUnexpectedEOF
+		}
+		return "", nil, err
+	}
+	sr.read++
+	return kv.Key, kv.Value, nil
+}
+
+func (sr *snapshotReader) verifyChecksum() error {
+	expected := sr.crc.Sum32()
+	checksum := &pbsubstreams.StoreSnapshotChecksum{}
+	if err := sr.readRecord(checksum); err != nil {
+		return fmt.Errorf("reading checksum: %w", unexpectedEOF(err))
+	}
+	if checksum.Crc32C != expected {
+		return fmt.Errorf("checksum mismatch: computed %08x, snapshot has %08x", expected, checksum.Crc32C)
+	}
+	if _, err := sr.r.ReadByte(); err != io.EOF {
+		return fmt.Errorf("unexpected data after checksum")
+	}
+	return io.EOF
+}
+
+// readAll passes the remaining keys to `put`, checking they match the count
+// of the header, and the checksum.
+func (sr *snapshotReader) readAll(put func(key string, value []byte)) error {
+	for {
+		key, value, err := sr.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if sr.read == sr.header.KeyCount {
+				return err
+			}
+			return fmt.Errorf("reading key %d: %w", sr.read, err)
+		}
+		put(key, value)
+	}
+	if sr.read != sr.header.KeyCount {
+		return fmt.Errorf("read %d keys, header announces %d", sr.read, sr.header.KeyCount)
+	}
+	return nil
+}
+
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// writeSnapshot writes the keys of `kv` sorted, after `header`.
+func writeSnapshot(w io.Writer, header *pbsubstreams.StoreSnapshotHeader, kv kvStore) error {
+	header.KeyCount = kv.len()
+
+	sw, err := newSnapshotWriter(w, header)
+	if err != nil {
+		return err
+	}
+	err = kv.iterRange("", "", func(key string, value []byte) error {
+		if err := sw.writeKeyValue(key, value); err != nil {
+			return fmt.Errorf("writing key %q: %w", key, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return sw.close()
+}
+
+func (s *BaseStore) snapshotHeader(partial bool) *pbsubstreams.StoreSnapshotHeader {
+	return &pbsubstreams.StoreSnapshotHeader{
+		ModuleHash:   s.moduleHash,
+		ValueType:    s.valueType,
+		UpdatePolicy: s.updatePolicy,
+		Partial:      partial,
+	}
+}
+
+// checkSnapshotHeader rejects the snapshots of other modules, or of the wrong
+// kind, unless the store was opened without a module hash, as by the tools.
+func (s *BaseStore) checkSnapshotHeader(header *pbsubstreams.StoreSnapshotHeader, partial, delta bool) error {
+	if header.Partial != partial {
+		return fmt.Errorf("snapshot partial is %t, expected %t", header.Partial, partial)
+	}
+	if header.Delta != delta {
+		return fmt.Errorf("snapshot delta is %t, expected %t", header.Delta, delta)
+	}
+	if s.moduleHash != "" && header.ModuleHash != s.moduleHash {
+		return fmt.Errorf("snapshot of module hash %q, expected %q", header.ModuleHash, s.moduleHash)
+	}
+	return nil
+}
+
+// readFullSnapshot passes the keys of a `.kv` snapshot, binary or JSON, to
+// `put` as they are read.
+func (s *BaseStore) readFullSnapshot(r io.Reader, put func(key string, value []byte)) error {
+	buffered := bufio.NewReader(r)
+	if !peekBinarySnapshot(buffered) {
+		kv := map[string][]byte{}
+		if err := json.NewDecoder(buffered).Decode(&kv); err != nil {
+			return corrupted(fmt.Errorf("unmarshal data: %w", err))
+		}
+		for key, value := range kv {
+			put(key, value)
+		}
+		return nil
+	}
+
+	sr, err := newSnapshotReader(buffered)
+	if err != nil {
+		return corrupted(fmt.Errorf("unmarshal data: %w", err))
+	}
+	if err := s.checkSnapshotHeader(sr.header, false, false); err != nil {
+		return err
+	}
+	if err := sr.readAll(put); err != nil {
+		return corrupted(fmt.Errorf("unmarshal data: %w", err))
+	}
+	return nil
+}
+
+// readPartialSnapshot passes the keys of a `.partial` snapshot, binary or
+// JSON, to `put` as they are read, and returns its deletions.
+func (s *BaseStore) readPartialSnapshot(r io.Reader, put func(key string, value []byte)) (*storeData, error) {
+	buffered := bufio.NewReader(r)
+	if !peekBinarySnapshot(buffered) {
+		stateData := &storeData{}
+		if err := json.NewDecoder(buffered).Decode(&stateData); err != nil {
+			return nil, corrupted(fmt.Errorf("unmarshal data: %w", err))
+		}
+		for key, value := range stateData.KV {
+			put(key, value)
+		}
+		stateData.KV = nil
+		return stateData, nil
+	}
+
+	sr, err := newSnapshotReader(buffered)
+	if err != nil {
+		return nil, corrupted(fmt.Errorf("unmarshal data: %w", err))
+	}
+	if err := s.checkSnapshotHeader(sr.header, true, false); err != nil {
+		return nil, err
+	}
+	if err := sr.readAll(put); err != nil {
+		return nil, corrupted(fmt.Errorf("unmarshal data: %w", err))
+	}
+
+	stateData := &storeData{
+		DeletedPrefixes: sr.header.DeletedPrefixes,
+		DeletedKeys:     sr.header.DeletedKeys,
+	}
+	for _, r := range sr.header.DeletedRanges {
+		stateData.DeletedRanges = append(stateData.DeletedRanges, &KeyRange{Low: r.Low, High: r.High})
+	}
+	return stateData, nil
+}
+
+// loadKV loads a snapshot into a new kv, on disk when needed, with `read`
+// passing it the keys.
+func (s *BaseStore) loadKV(ctx context.Context, filename string, read func(r io.Reader, put func(key string, value []byte)) error) (kvStore, error) {
+	var kv kvStore
+	err := loadStore(ctx, s.store, filename, func(r io.Reader) (err error) {
+		if kv != nil { // retried
+			kv.close()
+		}
+		if kv, err = s.newKV(); err != nil {
+			return err
+		}
+		if err := read(r, func(key string, value []byte) {
+			kv.set(key, value)
+			kv = s.spill(kv)
+		}); err != nil {
+			return err
+		}
+		return kv.err()
+	})
+	if err != nil {
+		if kv != nil {
+			kv.close()
+		}
+		return nil, err
+	}
+	return kv, nil
+}
@@ -0,0 +1,284 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/streamingfast/dstore"
+	"github.com/streamingfast/substreams/compress"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestFileStore(t *testing.T) dstore.Store {
+	t.Helper()
+	store, err := dstore.NewStore("file://"+t.TempDir(), "", "", false)
+	require.NoError(t, err)
+	return store
+}
+
+func TestFullKV_SaveLoad(t *testing.T) {
+	forEachKVBackend(t, func(t *testing.T) {
+		ctx := context.Background()
+		fileStore := newTestFileStore(t)
+
+		s := NewTestKVStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", fileStore)
+		useTestBackend(t, s.BaseStore)
+		s.Set(0, "b", "2")
+		s.Set(1, "a", "1")
+		s.Set(2, "empty", "")
+		_, err := s.Save(ctx, 100)
+		require.NoError(t, err)
+
+		assert.True(t, isBinarySnapshot(mustLoad(t, s.store, "0000000100-0000000000.kv")))
+
+		loaded := NewTestKVStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", fileStore)
+		useTestBackend(t, loaded.BaseStore)
+		require.NoError(t, loaded.Load(ctx, 100))
+		assert.Equal(t, map[string]string{"a": "1", "b": "2", "empty": ""}, kvStrings(loaded.kv))
+
+		info, err := loaded.InspectSnapshot(ctx, &FileInfo{Filename: "0000000100-0000000000.kv", EndBlock: 100})
+		require.NoError(t, err)
+		assert.Equal(t, SnapshotFormatBinary, info.Format)
+		assert.Equal(t, uint64(3), info.KeyCount)
+		assert.Equal(t, "test.module.hash", info.Header.ModuleHash)
+		assert.Equal(t, "string", info.Header.ValueType)
+		assert.Equal(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, info.Header.UpdatePolicy)
+	})
+}
+
+func TestPartialKV_SaveLoad(t *testing.T) {
+	forEachKVBackend(t, func(t *testing.T) {
+		ctx := context.Background()
+		fileStore := newTestFileStore(t)
+
+		s := NewTestKVPartialStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", fileStore, 100)
+		useTestBackend(t, s.BaseStore)
+		s.Set(0, "a", "1")
+		s.DeletePrefix(1, "p:")
+		s.Del(2, "k")
+		s.DeleteRange(3, "b", "d")
+		_, err := s.Save(ctx, 200)
+		require.NoError(t, err)
+
+		loaded := NewTestKVPartialStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", fileStore, 100)
+		useTestBackend(t, loaded.BaseStore)
+		require.NoError(t, loaded.Load(ctx, 200))
+		assert.Equal(t, map[string]string{"a": "1"}, kvStrings(loaded.kv))
+		assert.Equal(t, []string{"p:"}, loaded.DeletedPrefixes)
+		assert.Equal(t, []string{"k"}, loaded.DeletedKeys)
+		assert.Equal(t, []*KeyRange{{Low: "b", High: "d"}}, loaded.DeletedRanges)
+
+		full := NewTestKVStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", fileStore)
+		useTestBackend(t, full.BaseStore)
+		mustSave(t, full.store, "0000000200-0000000000.kv", mustLoad(t, full.store, "0000000200-0000000100.partial"), compress.Default)
+		require.EqualError(t, full.Load(ctx, 200), "load full store test at 0000000200-0000000000.kv: snapshot partial is true, expected false")
+	})
+}
+
+func TestSnapshot_compression(t *testing.T) {
+	ctx := context.Background()
+	fileStore := newTestFileStore(t)
+
+	s := NewTestKVStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", fileStore)
+	s.Set(0, "a", "1")
+	_, err := s.Save(ctx, 100)
+	require.NoError(t, err)
+
+	s.SetCompression(compress.Compression{Codec: compress.Gzip, Level: 9})
+	s.Set(1, "b", "2")
+	_, err = s.Save(ctx, 200)
+	require.NoError(t, err)
+
+	for filename, compressed := range map[string]bool{"0000000100-0000000000.kv": false, "0000000200-0000000000.kv": true} {
+		r, err := fileStore.OpenObject(ctx, "test.module.hash/states/"+filename)
+		require.NoError(t, err)
+		raw, err := io.ReadAll(r)
+		require.NoError(t, err)
+		r.Close()
+		assert.Equal(t, !compressed, isBinarySnapshot(raw), "%s compressed", filename)
+	}
+
+	loaded := NewTestKVStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", fileStore)
+	require.NoError(t, loaded.Load(ctx, 100))
+	assert.Equal(t, map[string]string{"a": "1"}, kvStrings(loaded.kv))
+	require.NoError(t, loaded.Load(ctx, 200))
+	assert.Equal(t, map[string]string{"a": "1", "b": "2"}, kvStrings(loaded.kv))
+}
+
+func TestSnapshot_legacyJSON(t *testing.T) {
+	forEachKVBackend(t, func(t *testing.T) {
+		ctx := context.Background()
+		fileStore := newTestFileStore(t)
+		s := NewTestKVStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", fileStore)
+		useTestBackend(t, s.BaseStore)
+
+		mustSave(t, s.store, "0000000100-0000000000.kv", []byte(`{
+	  "a": "MQ=="
+	}`), compress.Compression{Codec: compress.None})
+		require.NoError(t, s.Load(ctx, 100))
+		assert.Equal(t, map[string]string{"a": "1"}, kvStrings(s.kv))
+
+		mustSave(t, s.store, "0000000200-0000000100.partial", []byte(`{
+	  "kv": {"b": "Mg=="},
+	  "deleted_prefixes": ["p:"],
+	  "deleted_ranges": [{"low": "c", "high": "d"}]
+	}`), compress.Compression{Codec: compress.None})
+		partial := NewTestKVPartialStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", fileStore, 100)
+		useTestBackend(t, partial.BaseStore)
+		require.NoError(t, partial.Load(ctx, 200))
+		assert.Equal(t, map[string]string{"b": "2"}, kvStrings(partial.kv))
+		assert.Equal(t, []string{"p:"}, partial.DeletedPrefixes)
+		assert.Equal(t, []*KeyRange{{Low: "c", High: "d"}}, partial.DeletedRanges)
+
+		info, err := s.InspectSnapshot(ctx, &FileInfo{Filename: "0000000200-0000000100.partial", StartBlock: 100, EndBlock: 200, Partial: true})
+		require.NoError(t, err)
+		assert.Equal(t, SnapshotFormatJSON, info.Format)
+		assert.Equal(t, uint64(1), info.KeyCount)
+		assert.Nil(t, info.Header)
+	})
+}
+
+func TestSnapshot_corrupted(t *testing.T) {
+	header := &pbsubstreams.StoreSnapshotHeader{ModuleHash: "abc"}
+	data, err := marshalSnapshot(header, map[string][]byte{"a": []byte("1"), "b": []byte("2")})
+	require.NoError(t, err)
+
+	_, _, err = unmarshalSnapshot(data[:len(data)-1])
+	assert.EqualError(t, err, "reading checksum: unexpected EOF")
+
+	_, _, err = unmarshalSnapshot(data[:len(data)-checksumRecordSize-1])
+	assert.EqualError(t, err, "reading key 1: unexpected EOF")
+
+	_, _, err = unmarshalSnapshot(data[:len(data)-checksumRecordSize-7]) // without the last key
+	assert.EqualError(t, err, "reading key 1: unexpected EOF")
+
+	flipped := append([]byte{}, data...)
+	flipped[len(flipped)-checksumRecordSize-1] = '3' // value of "b"
+	_, _, err = unmarshalSnapshot(flipped)
+	assert.Regexp(t, "^checksum mismatch: computed [0-9a-f]{8}, snapshot has [0-9a-f]{8}$", err.Error())
+
+	_, _, err = unmarshalSnapshot(append(append([]byte{}, data...), 0))
+	assert.EqualError(t, err, "unexpected data after checksum")
+
+	unknownVersion, err := marshalSnapshot(header, nil)
+	require.NoError(t, err)
+	unknownVersion[len(snapshotMagic)+2] = 9 // version field value
+	_, _, err = unmarshalSnapshot(unknownVersion)
+	assert.EqualError(t, err, "unsupported snapshot version 9")
+
+	s := NewTestKVStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", nil)
+	err = s.readFullSnapshot(bytes.NewReader(data), func(string, []byte) {})
+	assert.EqualError(t, err, `snapshot of module hash "abc", expected "test.module.hash"`)
+	assert.False(t, errors.Is(err, ErrCorruptedSnapshot))
+
+	s.moduleHash = "abc"
+	err = s.readFullSnapshot(bytes.NewReader(flipped), func(string, []byte) {})
+	assert.True(t, errors.Is(err, ErrCorruptedSnapshot))
+}
+
+// checksumRecordSize is the length prefix, tag and fixed32 of the checksum.
+const checksumRecordSize = 6
+
+func TestSnapshot_versionWithoutChecksum(t *testing.T) {
+	header := &pbsubstreams.StoreSnapshotHeader{ModuleHash: "abc"}
+	data, err := marshalSnapshot(header, map[string][]byte{"a": []byte("1"), "b": []byte("2")})
+	require.NoError(t, err)
+
+	v1 := append([]byte{}, data[:len(data)-checksumRecordSize]...)
+	v1[len(snapshotMagic)+2] = 1 // version field value
+	_, kv, err := unmarshalSnapshot(v1)
+	require.NoError(t, err)
+	assert.Len(t, kv, 2)
+
+	_, _, err = unmarshalSnapshot(v1[:len(v1)-7]) // without the last key
+	assert.EqualError(t, err, "read 1 keys, header announces 2")
+}
+
+func TestFullKV_corruptedSnapshot(t *testing.T) {
+	ctx := context.Background()
+	fileStore := newTestFileStore(t)
+
+	s := NewTestKVStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", fileStore)
+	s.Set(0, "a", "1")
+	s.Set(1, "b", "2")
+	_, err := s.Save(ctx, 100)
+	require.NoError(t, err)
+	data := mustLoad(t, s.store, "0000000100-0000000000.kv")
+	mustSave(t, s.store, "0000000100-0000000000.kv", data[:len(data)-3], compress.Default) // truncated upload
+
+	err = s.Clone().Load(ctx, 100)
+	assert.True(t, errors.Is(err, ErrCorruptedSnapshot), err)
+
+	require.NoError(t, s.DeleteCorruptedSnapshot(ctx, err))
+	files, err := s.ListSnapshotFiles(ctx)
+	require.NoError(t, err)
+	assert.Len(t, files, 0)
+
+	err = s.DeleteCorruptedSnapshot(ctx, fmt.Errorf("not found"))
+	assert.EqualError(t, err, "no corrupted snapshot file in error: not found")
+}
+
+func kvStrings(kv kvStore) map[string]string {
+	out := map[string]string{}
+	for k, v := range kvMap(kv) {
+		out[k] = string(v)
+	}
+	return out
+}
+
+func mustLoad(t *testing.T, store dstore.Store, filename string) (data []byte) {
+	t.Helper()
+	err := loadStore(context.Background(), store, filename, func(r io.Reader) (err error) {
+		data, err = io.ReadAll(r)
+		return err
+	})
+	require.NoError(t, err)
+	return data
+}
+
+func mustSave(t *testing.T, store dstore.Store, filename string, data []byte, compression compress.Compression) {
+	t.Helper()
+	err := saveStore(context.Background(), store, filename, compression, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+	require.NoError(t, err)
+}
+
+func marshalSnapshot(header *pbsubstreams.StoreSnapshotHeader, kv map[string][]byte) ([]byte, error) {
+	buffer := &bytes.Buffer{}
+	err := writeSnapshot(buffer, header, newMemoryKV(kv))
+	return buffer.Bytes(), err
+}
+
+func unmarshalSnapshot(data []byte) (*pbsubstreams.StoreSnapshotHeader, map[string][]byte, error) {
+	sr, err := newSnapshotReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, err
+	}
+	kv := map[string][]byte{}
+	err = sr.readAll(func(key string, value []byte) { kv[key] = value })
+	return sr.header, kv, err
+}
+
+func TestFullKV_Save_recordTooLarge(t *testing.T) {
+	defer func(size int) { maxSnapshotRecordSize = size }(maxSnapshotRecordSize)
+	maxSnapshotRecordSize = 64
+
+	s := NewTestKVStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", newTestFileStore(t))
+	s.Set(0, "small", "1")
+	s.Set(1, "large", string(make([]byte, 64)))
+	_, err := s.Save(context.Background(), 100)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `writing key "large": record of 73 bytes exceeds the maximum snapshot record size of 64 bytes`)
+
+	files, err := s.ListSnapshotFiles(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, files, 0)
+}
@@ -1,9 +1,15 @@
 package store
 
 import (
+	"bufio"
 	"context"
+	"errors"
 	"fmt"
+	"io"
+
 	"github.com/streamingfast/derr"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"go.uber.org/zap"
 )
 
 func (s *BaseStore) ListSnapshotFiles(ctx context.Context) (files []*FileInfo, err error) {
@@ -25,3 +31,107 @@ func (s *BaseStore) ListSnapshotFiles(ctx context.Context) (files []*FileInfo, e
 	}
 	return files, nil
 }
+
+// SnapshotInfo describes the content of a snapshot file.
+type SnapshotInfo struct {
+	*FileInfo
+
+	Format      string // `SnapshotFormatJSON` or `SnapshotFormatBinary`
+	KeyCount    uint64
+	Header      *pbsubstreams.StoreSnapshotHeader // nil for JSON snapshots
+	Checksummed bool                              // false for JSON and version 1 binary snapshots
+}
+
+// InspectSnapshot reads and decodes a snapshot file, in any format, failing
+// with an error matching `ErrCorruptedSnapshot` when it is corrupted. Binary
+// snapshots are decoded key by key, without loading them, and their checksum
+// is verified.
+func (s *BaseStore) InspectSnapshot(ctx context.Context, file *FileInfo) (*SnapshotInfo, error) {
+	info := &SnapshotInfo{FileInfo: file, Format: SnapshotFormatJSON}
+	err := loadStore(ctx, s.store, file.Filename, func(r io.Reader) error {
+		buffered := bufio.NewReader(r)
+		if !peekBinarySnapshot(buffered) {
+			return s.inspectJSONSnapshot(buffered, info)
+		}
+
+		sr, err := newSnapshotReader(buffered)
+		if err != nil {
+			return corrupted(err)
+		}
+		if sr.header.Partial != file.Partial {
+			return corrupted(fmt.Errorf("header partial is %t", sr.header.Partial))
+		}
+		if sr.header.Delta != file.Delta {
+			return corrupted(fmt.Errorf("header delta is %t", sr.header.Delta))
+		}
+		info.Format = SnapshotFormatBinary
+		info.Header = sr.header
+		info.Checksummed = sr.header.Version >= snapshotVersionChecksum
+		return corrupted(sr.readAll(func(string, []byte) { info.KeyCount++ }))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("decode snapshot %q: %w", file.Filename, err)
+	}
+	return info, nil
+}
+
+func (s *BaseStore) inspectJSONSnapshot(r io.Reader, info *SnapshotInfo) error {
+	count := func(string, []byte) { info.KeyCount++ }
+	if info.Partial {
+		_, err := s.readPartialSnapshot(r, count)
+		return err
+	}
+	return s.readFullSnapshot(r, count)
+}
+
+// DeleteCorruptedSnapshot deletes the snapshot file that failed to load with
+// `err`, matching `ErrCorruptedSnapshot`, so it can be produced again. The
+// delta segments applying on it, directly or through other deltas, are
+// deleted along with it, as they could not be loaded anymore.
+func (s *BaseStore) DeleteCorruptedSnapshot(ctx context.Context, err error) error {
+	var corruptedErr *corruptedSnapshotError
+	if !errors.As(err, &corruptedErr) || corruptedErr.filename == "" {
+		return fmt.Errorf("no corrupted snapshot file in error: %w", err)
+	}
+
+	filenames := []string{corruptedErr.filename}
+	if file, ok := parseFileName(corruptedErr.filename); ok && !file.Partial {
+		dependents, err := s.dependentDeltas(ctx, file.EndBlock)
+		if err != nil {
+			return err
+		}
+		filenames = append(filenames, dependents...)
+	}
+
+	for _, filename := range filenames {
+		if err := s.store.DeleteObject(ctx, filename); err != nil {
+			return fmt.Errorf("deleting snapshot %q: %w", filename, err)
+		}
+	}
+	s.logger.Warn("corrupted snapshot deleted", zap.String("filename", corruptedErr.filename), zap.Strings("dependent_deltas", filenames[1:]), zap.Error(err))
+	return nil
+}
+
+// dependentDeltas returns the delta segments applying on the full snapshot or
+// delta segment ending at `endBlock`, directly or through other deltas.
+func (s *BaseStore) dependentDeltas(ctx context.Context, endBlock uint64) ([]string, error) {
+	files, err := s.ListSnapshotFiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	deltas := map[uint64][]*FileInfo{} // by start block
+	for _, file := range files {
+		if file.Delta {
+			deltas[file.StartBlock] = append(deltas[file.StartBlock], file)
+		}
+	}
+
+	var out []string
+	for ends := []uint64{endBlock}; len(ends) != 0; ends = ends[1:] {
+		for _, delta := range deltas[ends[0]] {
+			out = append(out, delta.Filename)
+			ends = append(ends, delta.EndBlock)
+		}
+	}
+	return out, nil
+}
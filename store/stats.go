@@ -0,0 +1,268 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"math/bits"
+	"sort"
+	"strings"
+)
+
+// StatsOptions sets how `ComputeStats` groups the keys by prefix.
+type StatsOptions struct {
+	// PrefixSeparator splits the keys in segments, `:` when empty.
+	PrefixSeparator string
+	// PrefixDepth is the number of segments making a prefix, 1 when 0. Keys
+	// with fewer segments are their own prefix.
+	PrefixDepth int
+	// TopPrefixes is the number of prefixes reported, the ones with the most
+	// keys, 10 when 0.
+	TopPrefixes int
+}
+
+func (o StatsOptions) withDefaults() StatsOptions {
+	if o.PrefixSeparator == "" {
+		o.PrefixSeparator = ":"
+	}
+	if o.PrefixDepth == 0 {
+		o.PrefixDepth = 1
+	}
+	if o.TopPrefixes == 0 {
+		o.TopPrefixes = 10
+	}
+	return o
+}
+
+// Stats describes the content of a store.
+type Stats struct {
+	Block uint64 // end block of the snapshot, 0 when not computed from one
+
+	KeyCount   uint64
+	KeyBytes   uint64
+	ValueBytes uint64
+
+	MinValueSize uint64
+	MaxValueSize uint64
+	// ValueSizes is the distribution of the value sizes, in power of two
+	// buckets, only the non-empty ones.
+	ValueSizes []*SizeBucket
+	// TopPrefixes are the prefixes with the most keys, the most first.
+	TopPrefixes []*PrefixStats
+
+	prefixes map[string]*PrefixStats
+}
+
+// SizeBucket counts the values between `Min` and `Max` bytes, included.
+type SizeBucket struct {
+	Min   uint64
+	Max   uint64
+	Count uint64
+}
+
+// PrefixStats counts the keys starting with `Prefix`, and their bytes, keys
+// and values.
+type PrefixStats struct {
+	Prefix   string
+	KeyCount uint64
+	Bytes    uint64
+}
+
+func (s *Stats) TotalBytes() uint64 { return s.KeyBytes + s.ValueBytes }
+
+func (s *Stats) MeanValueSize() float64 {
+	if s.KeyCount == 0 {
+		return 0
+	}
+	return float64(s.ValueBytes) / float64(s.KeyCount)
+}
+
+// PrefixCount is the number of distinct prefixes.
+func (s *Stats) PrefixCount() int { return len(s.prefixes) }
+
+// ComputeStats iterates over the keys of `store` to describe its content.
+func ComputeStats(store Iterable, opts StatsOptions) (*Stats, error) {
+	opts = opts.withDefaults()
+	stats := &Stats{prefixes: map[string]*PrefixStats{}}
+	buckets := map[int]*SizeBucket{}
+
+	err := store.Iter(func(key string, value []byte) error {
+		size := uint64(len(value))
+		if stats.KeyCount == 0 || size < stats.MinValueSize {
+			stats.MinValueSize = size
+		}
+		if size > stats.MaxValueSize {
+			stats.MaxValueSize = size
+		}
+		stats.KeyCount++
+		stats.KeyBytes += uint64(len(key))
+		stats.ValueBytes += size
+
+		bucket := bits.Len64(size)
+		if buckets[bucket] == nil {
+			buckets[bucket] = sizeBucket(bucket)
+		}
+		buckets[bucket].Count++
+
+		prefix := keyPrefix(key, opts.PrefixSeparator, opts.PrefixDepth)
+		prefixStats := stats.prefixes[prefix]
+		if prefixStats == nil {
+			prefixStats = &PrefixStats{Prefix: prefix}
+			stats.prefixes[prefix] = prefixStats
+		}
+		prefixStats.KeyCount++
+		prefixStats.Bytes += uint64(len(key)) + size
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, bucket := range buckets {
+		stats.ValueSizes = append(stats.ValueSizes, bucket)
+	}
+	sort.Slice(stats.ValueSizes, func(i, j int) bool {
+		return stats.ValueSizes[i].Min < stats.ValueSizes[j].Min
+	})
+
+	for _, prefix := range stats.prefixes {
+		stats.TopPrefixes = append(stats.TopPrefixes, prefix)
+	}
+	sort.Slice(stats.TopPrefixes, func(i, j int) bool {
+		a, b := stats.TopPrefixes[i], stats.TopPrefixes[j]
+		if a.KeyCount != b.KeyCount {
+			return a.KeyCount > b.KeyCount
+		}
+		return a.Prefix < b.Prefix
+	})
+	if len(stats.TopPrefixes) > opts.TopPrefixes {
+		stats.TopPrefixes = stats.TopPrefixes[:opts.TopPrefixes]
+	}
+	return stats, nil
+}
+
+// sizeBucket returns the bucket of the sizes of `bits.Len64` `length`: 0,
+// then 1, 2-3, 4-7, ...
+func sizeBucket(length int) *SizeBucket {
+	if length == 0 {
+		return &SizeBucket{}
+	}
+	min := uint64(1) << (length - 1)
+	return &SizeBucket{Min: min, Max: min<<1 - 1}
+}
+
+func keyPrefix(key, separator string, depth int) string {
+	end := 0
+	for i := 0; i < depth; i++ {
+		next := strings.Index(key[end:], separator)
+		if next == -1 {
+			return key
+		}
+		end += next + len(separator)
+	}
+	return key[:end]
+}
+
+// Stats loads the state of the store at `atBlock`, from its snapshots, and
+// computes its statistics.
+func (s *FullKV) Stats(ctx context.Context, atBlock uint64, opts StatsOptions) (*Stats, error) {
+	if err := s.Load(ctx, atBlock); err != nil {
+		return nil, err
+	}
+	stats, err := ComputeStats(s, opts)
+	if err != nil {
+		return nil, fmt.Errorf("computing stats of store %q: %w", s.name, err)
+	}
+	stats.Block = atBlock
+	return stats, nil
+}
+
+// StatsDiff tells how a store changed between two snapshots.
+type StatsDiff struct {
+	From *Stats
+	To   *Stats
+
+	KeyCount   int64
+	TotalBytes int64
+	// Prefixes are the prefixes which grew or shrank the most, in bytes, the
+	// most first.
+	Prefixes []*PrefixDiff
+}
+
+// PrefixDiff is the change of the keys starting with `Prefix`.
+type PrefixDiff struct {
+	Prefix   string
+	KeyCount int64
+	Bytes    int64
+}
+
+// Blocks is the number of blocks between the two snapshots.
+func (d *StatsDiff) Blocks() uint64 {
+	if d.To.Block < d.From.Block {
+		return 0
+	}
+	return d.To.Block - d.From.Block
+}
+
+// BytesPerBlock is the average growth of the store, in bytes per block.
+func (d *StatsDiff) BytesPerBlock() float64 {
+	if d.Blocks() == 0 {
+		return 0
+	}
+	return float64(d.TotalBytes) / float64(d.Blocks())
+}
+
+// DiffStats compares the stats of a store at two snapshots, `from` being the
+// earlier one, reporting the `top` prefixes changing the most.
+func DiffStats(from, to *Stats, top int) *StatsDiff {
+	diff := &StatsDiff{
+		From:       from,
+		To:         to,
+		KeyCount:   int64(to.KeyCount) - int64(from.KeyCount),
+		TotalBytes: int64(to.TotalBytes()) - int64(from.TotalBytes()),
+	}
+
+	prefixes := map[string]*PrefixDiff{}
+	prefixDiff := func(prefix string) *PrefixDiff {
+		if prefixes[prefix] == nil {
+			prefixes[prefix] = &PrefixDiff{Prefix: prefix}
+		}
+		return prefixes[prefix]
+	}
+	for prefix, stats := range to.prefixes {
+		d := prefixDiff(prefix)
+		d.KeyCount += int64(stats.KeyCount)
+		d.Bytes += int64(stats.Bytes)
+	}
+	for prefix, stats := range from.prefixes {
+		d := prefixDiff(prefix)
+		d.KeyCount -= int64(stats.KeyCount)
+		d.Bytes -= int64(stats.Bytes)
+	}
+
+	for _, d := range prefixes {
+		if d.KeyCount != 0 || d.Bytes != 0 {
+			diff.Prefixes = append(diff.Prefixes, d)
+		}
+	}
+	sort.Slice(diff.Prefixes, func(i, j int) bool {
+		a, b := abs(diff.Prefixes[i].Bytes), abs(diff.Prefixes[j].Bytes)
+		if a != b {
+			return a > b
+		}
+		return diff.Prefixes[i].Prefix < diff.Prefixes[j].Prefix
+	})
+	if top == 0 {
+		top = StatsOptions{}.withDefaults().TopPrefixes
+	}
+	if len(diff.Prefixes) > top {
+		diff.Prefixes = diff.Prefixes[:top]
+	}
+	return diff
+}
+
+func abs(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
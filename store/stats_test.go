@@ -0,0 +1,84 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeStats(t *testing.T) {
+	s := NewTestKVStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", nil)
+	s.Set(0, "pool:a:1", "")
+	s.Set(1, "pool:b:2", "x")
+	s.Set(2, "pool:b:3", "xyz")
+	s.Set(3, "token:a", "01234567")
+	s.Set(4, "total", "12")
+
+	stats, err := ComputeStats(s, StatsOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), stats.KeyCount)
+	assert.Equal(t, uint64(36), stats.KeyBytes)
+	assert.Equal(t, uint64(14), stats.ValueBytes)
+	assert.Equal(t, uint64(50), stats.TotalBytes())
+	assert.Equal(t, uint64(0), stats.MinValueSize)
+	assert.Equal(t, uint64(8), stats.MaxValueSize)
+	assert.Equal(t, []*SizeBucket{
+		{Min: 0, Max: 0, Count: 1},
+		{Min: 1, Max: 1, Count: 1},
+		{Min: 2, Max: 3, Count: 2},
+		{Min: 8, Max: 15, Count: 1},
+	}, stats.ValueSizes)
+	assert.Equal(t, []*PrefixStats{
+		{Prefix: "pool:", KeyCount: 3, Bytes: 28},
+		{Prefix: "token:", KeyCount: 1, Bytes: 15},
+		{Prefix: "total", KeyCount: 1, Bytes: 7},
+	}, stats.TopPrefixes)
+
+	stats, err = ComputeStats(s, StatsOptions{PrefixDepth: 2, TopPrefixes: 2})
+	require.NoError(t, err)
+	assert.Equal(t, 4, stats.PrefixCount())
+	assert.Equal(t, []*PrefixStats{
+		{Prefix: "pool:b:", KeyCount: 2, Bytes: 20},
+		{Prefix: "pool:a:", KeyCount: 1, Bytes: 8},
+	}, stats.TopPrefixes)
+}
+
+func TestFullKV_StatsDiff(t *testing.T) {
+	ctx := context.Background()
+	fileStore := newTestFileStore(t)
+	s := NewTestKVStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", fileStore)
+
+	s.Set(0, "a:1", "1")
+	s.Set(1, "b:1", "1")
+	_, err := s.Save(ctx, 100)
+	require.NoError(t, err)
+	s.Reset()
+	s.Set(0, "a:2", "22")
+	s.Set(1, "a:3", "33")
+	s.Del(2, "b:1")
+	s.Set(3, "c", "3")
+	_, err = s.Save(ctx, 200)
+	require.NoError(t, err)
+
+	loaded := NewTestKVStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", fileStore)
+	from, err := loaded.Stats(ctx, 100, StatsOptions{})
+	require.NoError(t, err)
+	to, err := loaded.Stats(ctx, 200, StatsOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), from.KeyCount)
+	assert.Equal(t, uint64(4), to.KeyCount)
+
+	diff := DiffStats(from, to, 0)
+	assert.Equal(t, uint64(100), diff.Blocks())
+	assert.Equal(t, int64(2), diff.KeyCount)
+	assert.Equal(t, int64(8), diff.TotalBytes)
+	assert.Equal(t, 0.08, diff.BytesPerBlock())
+	assert.Equal(t, []*PrefixDiff{
+		{Prefix: "a:", KeyCount: 2, Bytes: 10},
+		{Prefix: "b:", KeyCount: -1, Bytes: -4},
+		{Prefix: "c", KeyCount: 1, Bytes: 2},
+	}, diff.Prefixes)
+}
@@ -71,3 +71,19 @@ func (s *BaseStore) SetMaxBigFloat(ord uint64, key string, value *big.Float) {
 	}
 	s.set(ord, key, []byte(max.Text('g', -1)))
 }
+
+func (s *BaseStore) SetMaxBigDecimal(ord uint64, key string, value *big.Rat) {
+	max := new(big.Rat)
+	val, found := s.GetAt(ord, key)
+	if !found {
+		max = value
+	} else {
+		prev, err := ParseBigDecimal(string(val))
+		if err != nil || value.Cmp(prev) > 0 {
+			max = value
+		} else {
+			max = prev
+		}
+	}
+	s.set(ord, key, []byte(BigDecimalToString(max)))
+}
@@ -47,9 +47,9 @@ func TestStoreSetMaxBigInt(t *testing.T) {
 	}
 
 	initTestStore := func(b *BaseStore, key string, value *big.Int) {
-		b.kv = map[string][]byte{}
+		b.kv = newMemoryKV(nil)
 		if value != nil {
-			b.kv[key] = []byte(value.String())
+			b.kv.set(key, []byte(value.String()))
 		}
 	}
 
@@ -113,9 +113,9 @@ func TestStoreSetMaxInt64(t *testing.T) {
 	}
 
 	initTestStore := func(b *BaseStore, key string, value *int64) {
-		b.kv = map[string][]byte{}
+		b.kv = newMemoryKV(nil)
 		if value != nil {
-			b.kv[key] = []byte(fmt.Sprintf("%d", *value))
+			b.kv.set(key, []byte(fmt.Sprintf("%d", *value)))
 		}
 	}
 
@@ -180,9 +180,9 @@ func TestStoreSetMaxFloat64(t *testing.T) {
 	}
 
 	initTestStore := func(b *BaseStore, key string, value *float64) {
-		b.kv = map[string][]byte{}
+		b.kv = newMemoryKV(nil)
 		if value != nil {
-			b.kv[key] = []byte(strconv.FormatFloat(*value, 'g', 100, 64))
+			b.kv.set(key, []byte(strconv.FormatFloat(*value, 'g', 100, 64)))
 		}
 	}
 
@@ -240,9 +240,9 @@ func TestStoreSetMaxBigFloat(t *testing.T) {
 	}
 
 	initTestStore := func(b *BaseStore, key string, value *big.Float) {
-		b.kv = map[string][]byte{}
+		b.kv = newMemoryKV(nil)
 		if value != nil {
-			b.kv[key] = []byte(value.Text('g', -1))
+			b.kv.set(key, []byte(value.Text('g', -1)))
 		}
 	}
 
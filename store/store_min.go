@@ -71,3 +71,19 @@ func (s *BaseStore) SetMinBigFloat(ord uint64, key string, value *big.Float) {
 	}
 	s.set(ord, key, []byte(min.Text('g', -1)))
 }
+
+func (s *BaseStore) SetMinBigDecimal(ord uint64, key string, value *big.Rat) {
+	min := new(big.Rat)
+	val, found := s.GetAt(ord, key)
+	if !found {
+		min = value
+	} else {
+		prev, err := ParseBigDecimal(string(val))
+		if err != nil || value.Cmp(prev) <= 0 {
+			min = value
+		} else {
+			min = prev
+		}
+	}
+	s.set(ord, key, []byte(BigDecimalToString(min)))
+}
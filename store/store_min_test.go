@@ -46,9 +46,9 @@ func TestStoreSetMinBigInt(t *testing.T) {
 	}
 
 	initTestStore := func(b *BaseStore, key string, value *big.Int) {
-		b.kv = map[string][]byte{}
+		b.kv = newMemoryKV(nil)
 		if value != nil {
-			b.kv[key] = []byte(value.String())
+			b.kv.set(key, []byte(value.String()))
 		}
 	}
 
@@ -112,9 +112,9 @@ func TestStoreSetMinInt64(t *testing.T) {
 	}
 
 	initTestStore := func(b *BaseStore, key string, value *int64) {
-		b.kv = map[string][]byte{}
+		b.kv = newMemoryKV(nil)
 		if value != nil {
-			b.kv[key] = []byte(fmt.Sprintf("%d", *value))
+			b.kv.set(key, []byte(fmt.Sprintf("%d", *value)))
 		}
 	}
 
@@ -179,9 +179,9 @@ func TestStoreSetMinFloat64(t *testing.T) {
 	}
 
 	initTestStore := func(b *BaseStore, key string, value *float64) {
-		b.kv = map[string][]byte{}
+		b.kv = newMemoryKV(nil)
 		if value != nil {
-			b.kv[key] = []byte(strconv.FormatFloat(*value, 'g', 100, 64))
+			b.kv.set(key, []byte(strconv.FormatFloat(*value, 'g', 100, 64)))
 		}
 	}
 
@@ -239,9 +239,9 @@ func TestStoreSetMinBigFloat(t *testing.T) {
 	}
 
 	initTestStore := func(b *BaseStore, key string, value *big.Float) {
-		b.kv = map[string][]byte{}
+		b.kv = newMemoryKV(nil)
 		if value != nil {
-			b.kv[key] = []byte(value.Text('g', -1))
+			b.kv.set(key, []byte(value.Text('g', -1)))
 		}
 	}
 
@@ -68,3 +68,19 @@ func (s *BaseStore) SumBigFloat(ord uint64, key string, value *big.Float) {
 	}
 	s.set(ord, key, []byte(sum.Text('g', 100)))
 }
+
+func (s *BaseStore) SumBigDecimal(ord uint64, key string, value *big.Rat) {
+	sum := new(big.Rat)
+	val, found := s.GetAt(ord, key)
+	if !found {
+		sum = value
+	} else {
+		prev, err := ParseBigDecimal(string(val))
+		if err != nil {
+			sum = value
+		} else {
+			sum.Add(prev, value)
+		}
+	}
+	s.set(ord, key, []byte(BigDecimalToString(sum)))
+}
@@ -37,7 +37,7 @@ func TestStoreSumBigInt(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			b := newTestBaseStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_UNSET, "", nil)
 			if test.existingValue != nil {
-				b.kv[test.key] = test.existingValue
+				b.kv.set(test.key, test.existingValue)
 			}
 
 			b.SumBigInt(0, test.key, test.value)
@@ -81,7 +81,7 @@ func TestStoreSumInt64(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			b := newTestBaseStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_UNSET, "", nil)
 			if test.existingValue != nil {
-				b.kv[test.key] = test.existingValue
+				b.kv.set(test.key, test.existingValue)
 			}
 
 			b.SumInt64(0, test.key, test.value)
@@ -126,7 +126,7 @@ func TestStoreSumFloat64(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			b := newTestBaseStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_UNSET, "", nil)
 			if test.existingValue != nil {
-				b.kv[test.key] = test.existingValue
+				b.kv.set(test.key, test.existingValue)
 			}
 
 			b.SumFloat64(0, test.key, test.value)
@@ -171,7 +171,7 @@ func TestStoreSumBigFloat(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			b := newTestBaseStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_UNSET, "", nil)
 			if test.existingValue != nil {
-				b.kv[test.key] = test.existingValue
+				b.kv.set(test.key, test.existingValue)
 			}
 
 			b.SumBigFloat(0, test.key, test.value)
@@ -8,7 +8,7 @@ import (
 )
 
 func NewTestKVStore(
-	t *testing.T,
+	t testing.TB,
 	updatePolicy pbsubstreams.Module_KindStore_UpdatePolicy,
 	valueType string,
 	store dstore.Store,
@@ -19,7 +19,7 @@ func NewTestKVStore(
 }
 
 func NewTestKVPartialStore(
-	t *testing.T,
+	t testing.TB,
 	updatePolicy pbsubstreams.Module_KindStore_UpdatePolicy,
 	valueType string,
 	store dstore.Store,
@@ -33,7 +33,7 @@ func NewTestKVPartialStore(
 }
 
 func newTestBaseStore(
-	t *testing.T,
+	t testing.TB,
 	updatePolicy pbsubstreams.Module_KindStore_UpdatePolicy,
 	valueType string,
 	store dstore.Store,
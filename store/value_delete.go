@@ -1,35 +1,24 @@
 package store
 
 import (
-	"strings"
-
 	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
 )
 
-//func (s *BaseStore) Del(ord uint64, key string) {
-//	s.bumpOrdinal(ord)
-//
-//	val, found := s.GetLast(key)
-//	if found {
-//		delta := &pbsubstreams.StoreDelta{
-//			Operation: pbsubstreams.StoreDelta_DELETE,
-//			Ordinal:   ord,
-//			Key:       key,
-//			OldValue:  val,
-//			NewValue:  nil,
-//		}
-//		s.ApplyDelta(delta)
-//		s.deltas = append(s.deltas, delta)
-//	}
-//}
+// KeyRange is a lexicographic range of keys, from `Low` included to `High` excluded.
+type KeyRange struct {
+	Low  string `json:"low"`
+	High string `json:"high"`
+}
 
-func (s *BaseStore) DeletePrefix(ord uint64, prefix string) {
+func (r *KeyRange) Contains(key string) bool {
+	return key >= r.Low && key < r.High
+}
+
+func (s *BaseStore) Del(ord uint64, key string) {
 	s.bumpOrdinal(ord)
 
-	for key, val := range s.kv {
-		if !strings.HasPrefix(key, prefix) {
-			continue
-		}
+	val, found := s.GetLast(key)
+	if found {
 		delta := &pbsubstreams.StoreDelta{
 			Operation: pbsubstreams.StoreDelta_DELETE,
 			Ordinal:   ord,
@@ -41,3 +30,40 @@ func (s *BaseStore) DeletePrefix(ord uint64, prefix string) {
 		s.deltas = append(s.deltas, delta)
 	}
 }
+
+func (s *BaseStore) DeleteRange(ord uint64, lowKey, highKey string) {
+	s.bumpOrdinal(ord)
+
+	if highKey <= lowKey {
+		return
+	}
+	s.deleteRange(ord, lowKey, highKey)
+}
+
+func (s *BaseStore) DeletePrefix(ord uint64, prefix string) {
+	s.bumpOrdinal(ord)
+
+	s.deleteRange(ord, prefix, prefixEnd(prefix))
+}
+
+// deleteRange deletes the keys from `low` to `high`, excluded, in lexical
+// order, so the produced deltas do not depend on the KV backend. The keys are
+// collected first, as the iteration must not modify them.
+func (s *BaseStore) deleteRange(ord uint64, low, high string) {
+	var deltas []*pbsubstreams.StoreDelta
+	s.kv.iterRange(low, high, func(key string, value []byte) error {
+		deltas = append(deltas, &pbsubstreams.StoreDelta{
+			Operation: pbsubstreams.StoreDelta_DELETE,
+			Ordinal:   ord,
+			Key:       key,
+			OldValue:  value,
+			NewValue:  nil,
+		})
+		return nil
+	})
+
+	for _, delta := range deltas {
+		s.ApplyDelta(delta)
+		s.deltas = append(s.deltas, delta)
+	}
+}
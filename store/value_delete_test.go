@@ -0,0 +1,83 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValueDel(t *testing.T) {
+	s := NewTestKVStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, OutputValueTypeString, nil)
+
+	s.Set(0, "a", "1")
+	s.Set(1, "b", "2")
+	s.Reset()
+
+	s.Del(2, "a")
+	s.Del(3, "unknown")
+
+	assert.False(t, s.HasLast("a"))
+	assert.True(t, s.HasLast("b"))
+	assert.True(t, s.HasAt(1, "a"))
+	assert.Equal(t, []*pbsubstreams.StoreDelta{
+		{Operation: pbsubstreams.StoreDelta_DELETE, Ordinal: 2, Key: "a", OldValue: []byte("1")},
+	}, s.GetDeltas())
+}
+
+func TestValueDeleteRange(t *testing.T) {
+	forEachKVBackend(t, func(t *testing.T) {
+		s := NewTestKVStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, OutputValueTypeString, nil)
+		useTestBackend(t, s.BaseStore)
+
+		s.Set(0, "k:1", "1")
+		s.Set(0, "k:2", "2")
+		s.Set(0, "k:3", "3")
+		s.Set(0, "k:30", "30")
+		s.Set(0, "k:4", "4")
+		s.Reset()
+
+		s.DeleteRange(1, "k:2", "k:4")
+		s.DeleteRange(2, "k:4", "")    // empty range
+		s.DeleteRange(3, "k:4", "k:1") // empty range
+
+		var remaining []string
+		require.NoError(t, s.ScanPrefix("", 0, func(key string, _ []byte) error {
+			remaining = append(remaining, key)
+			return nil
+		}))
+		assert.Equal(t, []string{"k:1", "k:4"}, remaining)
+		assert.Equal(t, []*pbsubstreams.StoreDelta{
+			{Operation: pbsubstreams.StoreDelta_DELETE, Ordinal: 1, Key: "k:2", OldValue: []byte("2")},
+			{Operation: pbsubstreams.StoreDelta_DELETE, Ordinal: 1, Key: "k:3", OldValue: []byte("3")},
+			{Operation: pbsubstreams.StoreDelta_DELETE, Ordinal: 1, Key: "k:30", OldValue: []byte("30")},
+		}, s.GetDeltas())
+	})
+}
+
+func TestPartialKV_DeletesSaveLoad(t *testing.T) {
+	ctx := context.Background()
+	p := NewTestKVPartialStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, OutputValueTypeString, nil, 0)
+
+	p.Set(0, "kept", "1")
+	p.Del(1, "from_previous_segment")
+	p.DeleteRange(2, "a", "c")
+	p.DeletePrefix(3, "p:")
+
+	_, err := p.Save(ctx, 100)
+	require.NoError(t, err)
+
+	loaded := NewPartialKV(&BaseStore{name: p.name, store: p.store, logger: p.logger}, 0)
+	require.NoError(t, loaded.Load(ctx, 100))
+
+	assert.Equal(t, []string{"from_previous_segment"}, loaded.DeletedKeys)
+	assert.Equal(t, []*KeyRange{{Low: "a", High: "c"}}, loaded.DeletedRanges)
+	assert.Equal(t, []string{"p:"}, loaded.DeletedPrefixes)
+
+	loaded.Roll(100)
+	assert.Nil(t, loaded.DeletedKeys)
+	assert.Nil(t, loaded.DeletedRanges)
+	assert.Nil(t, loaded.DeletedPrefixes)
+}
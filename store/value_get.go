@@ -1,6 +1,7 @@
 package store
 
 import (
+	"errors"
 	"fmt"
 
 	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
@@ -21,8 +22,7 @@ func (s *BaseStore) GetFirst(key string) ([]byte, bool) {
 		}
 	}
 
-	val, found := s.kv[key]
-	return val, found
+	return s.kv.get(key)
 }
 
 func (s *BaseStore) GetLast(key string) ([]byte, bool) {
@@ -40,8 +40,7 @@ func (s *BaseStore) GetLast(key string) ([]byte, bool) {
 		}
 	}
 
-	val, found := s.kv[key]
-	return val, found
+	return s.kv.get(key)
 }
 
 // GetAt returns the key for the state that includes the processing of `ord`.
@@ -69,3 +68,35 @@ func (s *BaseStore) GetAt(ord uint64, key string) (out []byte, found bool) {
 	}
 	return
 }
+
+func (s *BaseStore) HasFirst(key string) bool {
+	_, found := s.GetFirst(key)
+	return found
+}
+
+func (s *BaseStore) HasLast(key string) bool {
+	_, found := s.GetLast(key)
+	return found
+}
+
+func (s *BaseStore) HasAt(ord uint64, key string) bool {
+	_, found := s.GetAt(ord, key)
+	return found
+}
+
+func (s *BaseStore) ScanPrefix(prefix string, limit uint64, f func(key string, value []byte) error) error {
+	var count uint64
+	err := s.kv.iterRange(prefix, prefixEnd(prefix), func(key string, value []byte) error {
+		if limit != 0 && count == limit {
+			return errScanLimitReached
+		}
+		count++
+		return f(key, value)
+	})
+	if err == errScanLimitReached {
+		return nil
+	}
+	return err
+}
+
+var errScanLimitReached = errors.New("scan limit reached")
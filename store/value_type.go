@@ -0,0 +1,69 @@
+package store
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// ValidateValue checks that `value` is a well-formed value of `valueType`, as
+// declared by a store module. Numeric types must parse, infinities and NaN
+// being rejected, and `proto:` types must be valid protobuf wire encoding.
+// Other value types accept any value.
+func ValidateValue(valueType string, value []byte) error {
+	if strings.HasPrefix(valueType, "proto:") {
+		if err := validateProtoWire(value); err != nil {
+			return fmt.Errorf("invalid %s value: %w", valueType, err)
+		}
+		return nil
+	}
+
+	switch strings.ToLower(valueType) {
+	case OutputValueTypeInt64:
+		if _, err := strconv.ParseInt(string(value), 10, 64); err != nil {
+			return fmt.Errorf("invalid int64 value %q", value)
+		}
+	case OutputValueTypeFloat64:
+		f, err := strconv.ParseFloat(string(value), 64)
+		if err != nil || math.IsInf(f, 0) || math.IsNaN(f) {
+			return fmt.Errorf("invalid float64 value %q", value)
+		}
+	case OutputValueTypeBigInt:
+		if _, ok := new(big.Int).SetString(string(value), 10); !ok {
+			return fmt.Errorf("invalid bigint value %q", value)
+		}
+	case OutputValueTypeBigFloat:
+		f, _, err := big.ParseFloat(string(value), 10, 100, big.ToNearestEven)
+		if err != nil || f.IsInf() {
+			return fmt.Errorf("invalid bigfloat value %q", value)
+		}
+	case OutputValueTypeBigDecimal:
+		if _, err := ParseBigDecimal(string(value)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateProtoWire walks the fields of an encoded message, without its
+// descriptor, nested messages being indistinguishable from bytes fields.
+func validateProtoWire(value []byte) error {
+	for len(value) > 0 {
+		num, typ, n := protowire.ConsumeTag(value)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		value = value[n:]
+
+		n = protowire.ConsumeFieldValue(num, typ, value)
+		if n < 0 {
+			return fmt.Errorf("field %d: %w", num, protowire.ParseError(n))
+		}
+		value = value[n:]
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package store
+
+import (
+	"strings"
+	"testing"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestValidateValue(t *testing.T) {
+	encoded, err := proto.Marshal(&pbsubstreams.StoreDelta{Key: "k", NewValue: []byte("v"), Ordinal: 3})
+	require.NoError(t, err)
+
+	tests := []struct {
+		valueType   string
+		value       []byte
+		expectError string // prefix, protobuf errors being randomized
+	}{
+		{"int64", []byte("-12"), ""},
+		{"int64", []byte("12.0"), `invalid int64 value "12.0"`},
+		{"int64", []byte("9223372036854775808"), `invalid int64 value "9223372036854775808"`},
+		{"float64", []byte("1.5e10"), ""},
+		{"float64", []byte("NaN"), `invalid float64 value "NaN"`},
+		{"float64", []byte("-Inf"), `invalid float64 value "-Inf"`},
+		{"bigint", []byte("-123456789012345678901234567890"), ""},
+		{"bigint", []byte("0x10"), `invalid bigint value "0x10"`},
+		{"bigint", []byte(""), `invalid bigint value ""`},
+		{"bigfloat", []byte("123456789012345678901234567890.5"), ""},
+		{"bigfloat", []byte("1,5"), `invalid bigfloat value "1,5"`},
+		{"bigfloat", []byte("+Inf"), `invalid bigfloat value "+Inf"`},
+		{"proto:sf.substreams.v1.StoreDelta", encoded, ""},
+		{"proto:sf.substreams.v1.StoreDelta", nil, ""},
+		{"proto:sf.substreams.v1.StoreDelta", encoded[:len(encoded)-1], "invalid proto:sf.substreams.v1.StoreDelta value: field 5: unexpected EOF"},
+		{"proto:sf.substreams.v1.StoreDelta", []byte{0x07}, "invalid proto:sf.substreams.v1.StoreDelta value: "},
+		{"string", []byte("anything"), ""},
+		{"bytes", []byte{0xff}, ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.valueType+" "+string(test.value), func(t *testing.T) {
+			err := ValidateValue(test.valueType, test.value)
+			if test.expectError == "" {
+				assert.NoError(t, err)
+			} else {
+				if assert.Error(t, err) {
+					assert.True(t, strings.HasPrefix(err.Error(), test.expectError), err.Error())
+				}
+			}
+		})
+	}
+}
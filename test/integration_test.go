@@ -16,6 +16,7 @@ import (
 	"github.com/streamingfast/dstore"
 	"github.com/streamingfast/substreams"
 	"github.com/streamingfast/substreams/block"
+	"github.com/streamingfast/substreams/compress"
 	"github.com/streamingfast/substreams/manifest"
 	"github.com/streamingfast/substreams/orchestrator"
 	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
@@ -23,6 +24,7 @@ import (
 	"github.com/streamingfast/substreams/pipeline"
 	"github.com/streamingfast/substreams/pipeline/execout/cachev1"
 	"github.com/streamingfast/substreams/store"
+	"github.com/streamingfast/substreams/wasm"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/proto"
@@ -112,7 +114,7 @@ func processRequest(t *testing.T, request *pbsubstreams.Request, moduleGraph *ma
 	baseStoreStore, err := dstore.NewStore("file:///tmp/test.store", "", "none", true)
 	require.NoError(t, err)
 
-	cachingEngine, err := cachev1.NewEngine(ctx, 10, baseStoreStore, zap.NewNop())
+	cachingEngine, err := cachev1.NewEngine(ctx, 10, compress.Default, baseStoreStore, zap.NewNop())
 	require.NoError(t, err)
 
 	storeGenerator := pipeline.NewStoreFactory(baseStoreStore, 10)
@@ -123,7 +125,7 @@ func processRequest(t *testing.T, request *pbsubstreams.Request, moduleGraph *ma
 		req,
 		moduleGraph,
 		"sf.substreams.v1.test.Block",
-		nil,
+		wasm.NewRuntime(nil),
 		10,
 		cachingEngine,
 		storeMap,
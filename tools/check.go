@@ -14,6 +14,7 @@ import (
 var checkCmd = &cobra.Command{
 	Use:   "check <store_url>",
 	Short: "checks the integrity of the kv files in a given store",
+	Long:  `Checks that every snapshot file, binary or legacy JSON, decodes and matches its checksum, and that partial files leave no hole between them`,
 	Args:  cobra.ExactArgs(1),
 	RunE:  checkE,
 }
@@ -25,7 +26,7 @@ func init() {
 func checkE(cmd *cobra.Command, args []string) error {
 	ctx := cmd.Context()
 
-	stateStore, _, err := newStore(args[0])
+	stateStore, _, err := newStore(args[0], 0)
 	if err != nil {
 		return fmt.Errorf("failed to create store: %w", err)
 	}
@@ -34,6 +35,18 @@ func checkE(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("listing snapshots: %w", err)
 	}
 
+	for _, file := range files {
+		info, err := stateStore.InspectSnapshot(ctx, file)
+		if err != nil {
+			return fmt.Errorf("**corrupted snapshot** %w", err)
+		}
+		checksum := "checksum ok"
+		if !info.Checksummed {
+			checksum = "no checksum"
+		}
+		fmt.Printf("%s: %s format, %d keys, %s\n", file.Filename, info.Format, info.KeyCount, checksum)
+	}
+
 	var prevRange *block.Range
 	for _, file := range files {
 		if !file.Partial {
@@ -56,7 +69,7 @@ func checkE(cmd *cobra.Command, args []string) error {
 	return err
 }
 
-func newStore(storeURL string) (*store.FullKV, dstore.Store, error) {
+func newStore(storeURL string, moduleInitialBlock uint64) (*store.FullKV, dstore.Store, error) {
 	remoteStore, err := dstore.NewStore(storeURL, "", "", false)
 	if err != nil {
 		return nil, nil, fmt.Errorf("could not create store from %s: %w", storeURL, err)
@@ -64,7 +77,7 @@ func newStore(storeURL string) (*store.FullKV, dstore.Store, error) {
 
 	s, err := store.NewFullKV(
 		"",
-		0,
+		moduleInitialBlock,
 		"",
 		pbsubstreams.Module_KindStore_UPDATE_POLICY_SET_IF_NOT_EXISTS,
 		"",
@@ -24,7 +24,7 @@ func cleanUpE(cmd *cobra.Command, args []string) error {
 	ctx := cmd.Context()
 
 	dsn := args[0]
-	store, remoteStore, err := newStore(dsn)
+	store, remoteStore, err := newStore(dsn, 0)
 	if err != nil {
 		return fmt.Errorf("creating store: %w", err)
 	}
@@ -17,6 +17,7 @@ import (
 	"github.com/streamingfast/substreams/manifest"
 	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
 	"github.com/streamingfast/substreams/pipeline/execout/cachev1"
+	"github.com/streamingfast/substreams/pipeline/execout/cachev2"
 )
 
 var decodeCmd = &cobra.Command{
@@ -104,7 +105,7 @@ func runDecodeModuleRunE(cmd *cobra.Command, args []string) error {
 	startBlock := cachev1.ComputeStartBlock(blockNumber, saveInterval)
 
 	switch matchingModule.Kind.(type) {
-	case *pbsubstreams.Module_KindMap_:
+	case *pbsubstreams.Module_KindMap_, *pbsubstreams.Module_KindIndex_:
 		return searchMapModule(ctx, blockNumber, startBlock, saveInterval, moduleHash, matchingModule, store, protoFiles)
 	case *pbsubstreams.Module_KindStore_:
 		if key == "" {
@@ -131,6 +132,7 @@ func searchMapModule(
 	}
 
 	outputCache := cachev1.NewOutputCache(module.Name, moduleStore, saveInterval, zlog)
+	outputCache.SetCodec(cachev2.Codec)
 	zlog.Info("loading block from store", zap.Uint64("start_block", startBlock), zap.Uint64("block_num", blockNumber))
 	found, err := outputCache.LoadAtBlock(ctx, startBlock)
 	if err != nil {
@@ -5,15 +5,26 @@ import (
 	"github.com/streamingfast/substreams/manifest"
 )
 
+// storeCmd groups the store tools. Given the arguments of `get`, it still
+// runs it, as it did before having sub-commands.
 var storeCmd = &cobra.Command{
-	Use:   "store get <manifest_path> <module_name> <block_id> <key>",
-	Short: "BaseStore files in a common archive format",
-	Long:  `BaseStore files in a common archive format`,
-	RunE:  storeGetE,
+	Use:   "store <manifest_path> <module_name> <block_id> <key>",
+	Short: "inspects the stores of a module",
+	Long: `Inspects the stores of a module: the value of a key with 'get', the contents of a full store snapshot
+with 'stats'. Called with the arguments of 'get', runs it.`,
+	Args: cobra.ExactArgs(4),
+	RunE: storeGetE,
+}
+
+var storeGetCmd = &cobra.Command{
+	Use:   "get <manifest_path> <module_name> <block_id> <key>",
+	Short: "gets the value of a key of a module's store at a block",
 	Args:  cobra.ExactArgs(4),
+	RunE:  storeGetE,
 }
 
 func init() {
+	storeCmd.AddCommand(storeGetCmd)
 	Cmd.AddCommand(storeCmd)
 }
 
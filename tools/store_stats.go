@@ -0,0 +1,140 @@
+package tools
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/streamingfast/substreams/store"
+)
+
+var storeStatsCmd = &cobra.Command{
+	Use:   "stats <store_url>",
+	Short: "reports the keys and sizes of a full store snapshot",
+	Long: `Reports the key count, total bytes, value size distribution and largest key prefixes of the full store
+state at a snapshot, the last one by default. With --diff-from, also compares it to the state at an earlier
+snapshot, to show how fast the store grows.`,
+	Args:         cobra.ExactArgs(1),
+	RunE:         storeStatsE,
+	SilenceUsage: true,
+}
+
+func init() {
+	storeStatsCmd.Flags().Uint64("block", 0, "End block of the snapshot, the last full snapshot when 0")
+	storeStatsCmd.Flags().Uint64("diff-from", 0, "End block of an earlier snapshot to compare to")
+	storeStatsCmd.Flags().Uint64("top", 10, "Number of key prefixes reported")
+	storeStatsCmd.Flags().String("prefix-separator", ":", "Separator of the key segments making the prefixes")
+	storeStatsCmd.Flags().Uint64("prefix-depth", 1, "Number of key segments making a prefix")
+
+	storeCmd.AddCommand(storeStatsCmd)
+}
+
+func storeStatsE(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	storeURL := args[0]
+	atBlock := mustGetUint64(cmd, "block")
+	diffFrom := mustGetUint64(cmd, "diff-from")
+	opts := store.StatsOptions{
+		PrefixSeparator: mustGetString(cmd, "prefix-separator"),
+		PrefixDepth:     int(mustGetUint64(cmd, "prefix-depth")),
+		TopPrefixes:     int(mustGetUint64(cmd, "top")),
+	}
+
+	stateStore, _, err := newStore(storeURL, 0)
+	if err != nil {
+		return fmt.Errorf("failed to create store: %w", err)
+	}
+	files, err := stateStore.ListSnapshotFiles(ctx)
+	if err != nil {
+		return fmt.Errorf("listing snapshots: %w", err)
+	}
+
+	// the full snapshots all start at the module initial block, which the
+	// store needs to name them
+	var initialBlock, lastBlock uint64
+	foundCheckpoint := false
+	for _, file := range files {
+		if file.Partial {
+			continue
+		}
+		if !file.Delta && (!foundCheckpoint || file.StartBlock < initialBlock) {
+			initialBlock = file.StartBlock
+			foundCheckpoint = true
+		}
+		if file.EndBlock > lastBlock {
+			lastBlock = file.EndBlock
+		}
+	}
+	if !foundCheckpoint {
+		return fmt.Errorf("no full snapshot found in %q", storeURL)
+	}
+	if atBlock == 0 {
+		atBlock = lastBlock
+	}
+	if diffFrom != 0 && diffFrom >= atBlock {
+		return fmt.Errorf("--diff-from block %d must be lower than the snapshot block %d", diffFrom, atBlock)
+	}
+
+	stateStore, _, err = newStore(storeURL, initialBlock)
+	if err != nil {
+		return fmt.Errorf("failed to create store: %w", err)
+	}
+	defer stateStore.Close()
+
+	stats, err := stateStore.Stats(ctx, atBlock, opts)
+	if err != nil {
+		return err
+	}
+	printStoreStats(stats)
+
+	if diffFrom == 0 {
+		return nil
+	}
+	from, err := stateStore.Stats(ctx, diffFrom, opts)
+	if err != nil {
+		return err
+	}
+	fmt.Println()
+	printStoreStatsDiff(store.DiffStats(from, stats, opts.TopPrefixes))
+	return nil
+}
+
+func printStoreStats(stats *store.Stats) {
+	fmt.Printf("Snapshot at block %d\n", stats.Block)
+	fmt.Printf("  keys: %d\n", stats.KeyCount)
+	fmt.Printf("  total bytes: %d (keys %d, values %d)\n", stats.TotalBytes(), stats.KeyBytes, stats.ValueBytes)
+	if stats.KeyCount == 0 {
+		return
+	}
+	fmt.Printf("  value size: min %d, mean %.1f, max %d\n", stats.MinValueSize, stats.MeanValueSize(), stats.MaxValueSize)
+
+	fmt.Println("Value sizes (bytes)")
+	for _, bucket := range stats.ValueSizes {
+		fmt.Printf("  %10d - %-10d %10d %6.2f%%\n", bucket.Min, bucket.Max, bucket.Count, percent(bucket.Count, stats.KeyCount))
+	}
+
+	fmt.Printf("Top %d of %d key prefixes\n", len(stats.TopPrefixes), stats.PrefixCount())
+	for _, prefix := range stats.TopPrefixes {
+		fmt.Printf("  %-30q %10d keys %12d bytes %6.2f%%\n", prefix.Prefix, prefix.KeyCount, prefix.Bytes, percent(prefix.Bytes, stats.TotalBytes()))
+	}
+}
+
+func printStoreStatsDiff(diff *store.StatsDiff) {
+	fmt.Printf("Growth from block %d to %d (%d blocks)\n", diff.From.Block, diff.To.Block, diff.Blocks())
+	fmt.Printf("  keys: %+d\n", diff.KeyCount)
+	fmt.Printf("  total bytes: %+d (%.2f bytes per block)\n", diff.TotalBytes, diff.BytesPerBlock())
+
+	if len(diff.Prefixes) == 0 {
+		return
+	}
+	fmt.Println("Key prefixes changing the most")
+	for _, prefix := range diff.Prefixes {
+		fmt.Printf("  %-30q %+10d keys %+12d bytes\n", prefix.Prefix, prefix.KeyCount, prefix.Bytes)
+	}
+}
+
+func percent(part, total uint64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) * 100 / float64(total)
+}
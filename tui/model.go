@@ -15,6 +15,7 @@ type model struct {
 	ui *TUI
 
 	Modules updatedRanges
+	Timings moduleTimings
 	BarMode bool
 	BarSize uint64
 
@@ -19,8 +19,8 @@ import (
 func (ui *TUI) decoratedBlockScopedData(output *pbsubstreams.BlockScopedData) error {
 	var s []string
 	for _, out := range output.Outputs {
-		for _, log := range out.Logs {
-			s = append(s, fmt.Sprintf("%s: log: %s\n", out.Name, log))
+		for idx, log := range out.Logs {
+			s = append(s, fmt.Sprintf("%s: %s: %s\n", out.Name, logLabel(out, idx), log))
 		}
 
 		switch data := out.Data.(type) {
@@ -105,6 +105,19 @@ func (ui *TUI) printJSONBlockDeltas(modName string, blockNum uint64, deltas []*p
 	return nil
 }
 
+// logLabel is the level of the log at `idx`, lowercase, or `log` when the
+// server did not send structured logs.
+func logLabel(out *pbsubstreams.ModuleOutput, idx int) string {
+	if idx >= len(out.StructuredLogs) {
+		return "log"
+	}
+	level := out.StructuredLogs[idx].Level
+	if level == pbsubstreams.LogLevel_LOG_LEVEL_UNSET {
+		return "log"
+	}
+	return strings.ToLower(strings.TrimPrefix(level.String(), "LOG_LEVEL_"))
+}
+
 func indent(in []byte) []byte {
 	return bytes.Replace(in, []byte("\n"), []byte("\n    "), -1)
 }
@@ -188,6 +201,7 @@ func (ui *TUI) formatPostDataProgress(msg *pbsubstreams.Response_Progress) {
 			fmt.Println("debug: still processing ranges after data?")
 		case *pbsubstreams.ModuleProgress_InitialState_:
 		case *pbsubstreams.ModuleProgress_ProcessedBytes_:
+		case *pbsubstreams.ModuleProgress_ProcessedTimings_:
 		case *pbsubstreams.ModuleProgress_Failed_:
 			failure := progMsg.Failed
 			if !displayedFailure {
@@ -0,0 +1,82 @@
+package tui
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"time"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// moduleTimings sums the ProcessedTimings received for each module, they
+// come from the server and from each of its sub-requests.
+type moduleTimings map[string]*pbsubstreams.ModuleProgress_ProcessedTimings
+
+// with returns a copy of the timings with `in` added to the module's, so the
+// bubbletea model can keep it without sharing it.
+func (t moduleTimings) with(module string, in *pbsubstreams.ModuleProgress_ProcessedTimings) moduleTimings {
+	out := make(moduleTimings, len(t)+1)
+	for k, v := range t {
+		out[k] = v
+	}
+
+	sum := &pbsubstreams.ModuleProgress_ProcessedTimings{}
+	if previous := t[module]; previous != nil {
+		sum = proto.Clone(previous).(*pbsubstreams.ModuleProgress_ProcessedTimings)
+	}
+	sum.Executions += in.Executions
+	sum.ExecutionTimeNs += in.ExecutionTimeNs
+	sum.CacheHits += in.CacheHits
+	sum.CacheHitTimeNs += in.CacheHitTimeNs
+	if in.MaxExecutionTimeNs > sum.MaxExecutionTimeNs {
+		sum.MaxExecutionTimeNs = in.MaxExecutionTimeNs
+	}
+	out[module] = sum
+	return out
+}
+
+// String renders one line per module, the slowest first.
+func (t moduleTimings) String() string {
+	var modules []string
+	for module := range t {
+		modules = append(modules, module)
+	}
+	total := func(module string) uint64 { return t[module].ExecutionTimeNs + t[module].CacheHitTimeNs }
+	sort.Slice(modules, func(i, j int) bool {
+		if total(modules[i]) == total(modules[j]) {
+			return modules[i] < modules[j]
+		}
+		return total(modules[i]) > total(modules[j])
+	})
+
+	buf := bytes.NewBuffer(nil)
+	fmt.Fprintf(buf, "%-25s %12s %12s %12s %12s %12s\n", "Module timings", "total", "executions", "avg", "max", "cache hits")
+	for _, module := range modules {
+		timing := t[module]
+		var avg time.Duration
+		if timing.Executions != 0 {
+			avg = time.Duration(timing.ExecutionTimeNs / timing.Executions)
+		}
+		fmt.Fprintf(buf, "%-25s %12s %12d %12s %12s %12d\n",
+			module,
+			roundDuration(time.Duration(total(module))),
+			timing.Executions,
+			roundDuration(avg),
+			roundDuration(time.Duration(timing.MaxExecutionTimeNs)),
+			timing.CacheHits,
+		)
+	}
+	return buf.String()
+}
+
+func roundDuration(d time.Duration) time.Duration {
+	switch {
+	case d > time.Second:
+		return d.Round(time.Millisecond)
+	case d > time.Millisecond:
+		return d.Round(time.Microsecond)
+	}
+	return d
+}
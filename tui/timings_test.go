@@ -0,0 +1,32 @@
+package tui
+
+import (
+	"testing"
+
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModuleTimings(t *testing.T) {
+	var timings moduleTimings
+	timings = timings.with("map_fast", &pbsubstreams.ModuleProgress_ProcessedTimings{Executions: 2, ExecutionTimeNs: 2_000, MaxExecutionTimeNs: 1_500})
+	first := timings
+	timings = timings.with("map_slow", &pbsubstreams.ModuleProgress_ProcessedTimings{Executions: 1, ExecutionTimeNs: 3_000_000, MaxExecutionTimeNs: 3_000_000})
+	timings = timings.with("map_fast", &pbsubstreams.ModuleProgress_ProcessedTimings{Executions: 2, ExecutionTimeNs: 6_000, MaxExecutionTimeNs: 5_000, CacheHits: 4, CacheHitTimeNs: 400})
+
+	assert.Len(t, first, 1)
+	assert.Equal(t, uint64(2), first["map_fast"].Executions, "previous copies are left untouched")
+
+	fast := timings["map_fast"]
+	assert.Equal(t, uint64(4), fast.Executions)
+	assert.Equal(t, uint64(8_000), fast.ExecutionTimeNs)
+	assert.Equal(t, uint64(5_000), fast.MaxExecutionTimeNs)
+	assert.Equal(t, uint64(4), fast.CacheHits)
+
+	assert.Equal(t, ""+
+		"Module timings                   total   executions          avg          max   cache hits\n"+
+		"map_slow                           3ms            1          3ms          3ms            0\n"+
+		"map_fast                         8.4µs            4          2µs          5µs            4\n",
+		timings.String(),
+	)
+}
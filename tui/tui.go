@@ -24,6 +24,7 @@ type TUI struct {
 
 	prog          *tea.Program
 	seenFirstData bool
+	timings       moduleTimings
 
 	msgDescs       map[string]*desc.MessageDescriptor
 	decodeMsgTypes map[string]func(in []byte) string
@@ -66,6 +67,8 @@ func (ui *TUI) Init(outputMode string) error {
 					msgType = modKind.KindStore.ValueType
 				case *pbsubstreams.Module_KindMap_:
 					msgType = modKind.KindMap.OutputType
+				case *pbsubstreams.Module_KindIndex_:
+					msgType = modKind.KindIndex.OutputType
 				}
 				msgType = strings.TrimPrefix(msgType, "proto:")
 
@@ -140,6 +143,11 @@ func (ui *TUI) IncomingMessage(resp *pbsubstreams.Response) error {
 			return ui.jsonBlockScopedData(m.Data)
 		}
 	case *pbsubstreams.Response_Progress:
+		for _, module := range m.Progress.Modules {
+			if timings := module.GetProcessedTimings(); timings != nil {
+				ui.timings = ui.timings.with(module.Name, timings)
+			}
+		}
 		if ui.seenFirstData {
 			ui.formatPostDataProgress(m)
 		} else {
@@ -168,6 +176,15 @@ func (ui *TUI) IncomingMessage(resp *pbsubstreams.Response) error {
 	return nil
 }
 
+// PrintTimings prints the time spent on each module, as reported by the
+// server so far.
+func (ui *TUI) PrintTimings() {
+	if len(ui.timings) == 0 {
+		return
+	}
+	fmt.Print(ui.timings.String())
+}
+
 func (ui *TUI) ensureTerminalUnlocked() {
 	if ui.prog == nil {
 		return
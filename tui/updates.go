@@ -71,6 +71,8 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.Modules = newModules
 		case *pbsubstreams.ModuleProgress_InitialState_:
 		case *pbsubstreams.ModuleProgress_ProcessedBytes_:
+		case *pbsubstreams.ModuleProgress_ProcessedTimings_:
+			m.Timings = m.Timings.with(msg.Name, progMsg.ProcessedTimings)
 		case *pbsubstreams.ModuleProgress_Failed_:
 			m.Failures += 1
 			if progMsg.Failed.Reason != "" {
@@ -22,6 +22,9 @@ Connected - Progress messages received: {{ .Updates }} ({{ .UpdatesPerSecond }}/
 {{ end }}
 {{- end -}}
 {{ end }}
+{{ with .Timings }}
+{{ .String }}
+{{- end }}
 {{ if .Failures }}
 Failures: {{ .Failures }}.
 Last failure:
@@ -73,6 +73,22 @@ func NewStoreDeltaInput(name string) *StoreDeltaInput {
 	}
 }
 
+type ParamsInput struct {
+	BaseArgument
+	BaseValueArgument
+}
+
+func NewParamsInput(value string) *ParamsInput {
+	return &ParamsInput{
+		BaseArgument: BaseArgument{
+			name: "params",
+		},
+		BaseValueArgument: BaseValueArgument{
+			value: []byte(value),
+		},
+	}
+}
+
 type StoreReaderInput struct {
 	BaseArgument
 	Store store.Store
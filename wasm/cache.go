@@ -0,0 +1,157 @@
+package wasm
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/bytecodealliance/wasmtime-go"
+	"go.uber.org/zap"
+)
+
+// DefaultModuleCacheSize is the number of compiled modules kept in memory by
+// the runtime, unless set with `WithModuleCacheSize`.
+const DefaultModuleCacheSize = 100
+
+// ModuleCache keeps compiled `wasmtime.Module` keyed by the hash of their
+// binary content, so that modules sharing the same code, within a request or
+// across requests, are compiled only once. It holds at most `maxModules`,
+// evicting the least recently used ones; instances created from an evicted
+// module keep it alive.
+//
+// When a directory is configured, compiled artifacts are also serialized to
+// disk and reloaded from there on the next process start. Only point it to a
+// directory fully controlled by the process, deserialized artifacts are
+// trusted as-is.
+type ModuleCache struct {
+	engine *wasmtime.Engine
+	dir    string
+
+	lock       sync.Mutex
+	maxModules int
+	modules    map[string]*list.Element // of *compiledModule
+	recent     *list.List               // most recently used first
+}
+
+type compiledModule struct {
+	key    string
+	once   sync.Once
+	module *wasmtime.Module
+	err    error
+}
+
+// NewModuleCache creates a cache holding up to `maxModules` compiled modules,
+// `DefaultModuleCacheSize` when zero.
+func NewModuleCache(engine *wasmtime.Engine, dir string, maxModules int) *ModuleCache {
+	if maxModules <= 0 {
+		maxModules = DefaultModuleCacheSize
+	}
+	return &ModuleCache{
+		engine:     engine,
+		dir:        dir,
+		maxModules: maxModules,
+		modules:    map[string]*list.Element{},
+		recent:     list.New(),
+	}
+}
+
+// Get returns the compiled module for `code`, compiling it on first use. Concurrent
+// callers asking for the same code wait on a single compilation.
+func (c *ModuleCache) Get(code []byte) (*wasmtime.Module, error) {
+	key := codeHash(code)
+
+	c.lock.Lock()
+	var entry *compiledModule
+	if element, found := c.modules[key]; found {
+		c.recent.MoveToFront(element)
+		entry = element.Value.(*compiledModule)
+	} else {
+		entry = &compiledModule{key: key}
+		c.modules[key] = c.recent.PushFront(entry)
+		for c.recent.Len() > c.maxModules {
+			oldest := c.recent.Remove(c.recent.Back()).(*compiledModule)
+			delete(c.modules, oldest.key)
+		}
+	}
+	c.lock.Unlock()
+
+	entry.once.Do(func() {
+		entry.module, entry.err = c.load(key, code)
+	})
+	return entry.module, entry.err
+}
+
+// Len returns the number of modules held.
+func (c *ModuleCache) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.recent.Len()
+}
+
+func (c *ModuleCache) load(key string, code []byte) (*wasmtime.Module, error) {
+	if c.dir == "" {
+		return wasmtime.NewModule(c.engine, code)
+	}
+
+	path := c.artifactPath(key)
+	if _, err := os.Stat(path); err == nil {
+		module, err := wasmtime.NewModuleDeserializeFile(c.engine, path)
+		if err == nil {
+			zlog.Debug("loaded compiled wasm module from disk", zap.String("path", path))
+			return module, nil
+		}
+		// Most likely produced by another wasmtime version or engine configuration, recompile and overwrite it
+		zlog.Info("ignoring unusable compiled wasm artifact", zap.String("path", path), zap.Error(err))
+	}
+
+	module, err := wasmtime.NewModule(c.engine, code)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.writeArtifact(path, module); err != nil {
+		zlog.Warn("unable to save compiled wasm module to disk", zap.String("path", path), zap.Error(err))
+	}
+	return module, nil
+}
+
+func (c *ModuleCache) artifactPath(key string) string {
+	return filepath.Join(c.dir, key+".cwasm")
+}
+
+func (c *ModuleCache) writeArtifact(path string, module *wasmtime.Module) error {
+	content, err := module.Serialize()
+	if err != nil {
+		return fmt.Errorf("serializing module: %w", err)
+	}
+
+	if err := os.MkdirAll(c.dir, os.ModePerm); err != nil {
+		return fmt.Errorf("creating cache directory: %w", err)
+	}
+
+	// Written under a temporary name then renamed, so a concurrent process never reads a partial artifact
+	tmpFile, err := os.CreateTemp(c.dir, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("creating temporary file: %w", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := tmpFile.Write(content); err != nil {
+		tmpFile.Close()
+		return fmt.Errorf("writing temporary file: %w", err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return fmt.Errorf("closing temporary file: %w", err)
+	}
+
+	return os.Rename(tmpFile.Name(), path)
+}
+
+func codeHash(code []byte) string {
+	sum := sha256.Sum256(code)
+	return hex.EncodeToString(sum[:])
+}
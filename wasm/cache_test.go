@@ -0,0 +1,85 @@
+package wasm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bytecodealliance/wasmtime-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// (module (func (export "noop")))
+var noopModuleCode = []byte{
+	0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00,
+	0x01, 0x04, 0x01, 0x60, 0x00, 0x00,
+	0x03, 0x02, 0x01, 0x00,
+	0x07, 0x08, 0x01, 0x04, 0x6e, 0x6f, 0x6f, 0x70, 0x00, 0x00,
+	0x0a, 0x04, 0x01, 0x02, 0x00, 0x0b,
+}
+
+func TestModuleCache_Get(t *testing.T) {
+	cache := NewModuleCache(wasmtime.NewEngine(), "", 0)
+
+	first, err := cache.Get(noopModuleCode)
+	require.NoError(t, err)
+
+	second, err := cache.Get(append([]byte{}, noopModuleCode...))
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+
+	_, err = cache.Get([]byte("not wasm"))
+	assert.Error(t, err)
+}
+
+func TestModuleCache_eviction(t *testing.T) {
+	cache := NewModuleCache(wasmtime.NewEngine(), "", 2)
+
+	// (module (func (export "noop"))), with the export renamed
+	code := func(name byte) []byte {
+		c := append([]byte{}, noopModuleCode...)
+		c[len(c)-9] = name
+		return c
+	}
+
+	a, err := cache.Get(code('a'))
+	require.NoError(t, err)
+	_, err = cache.Get(code('b'))
+	require.NoError(t, err)
+	_, err = cache.Get(code('a')) // b is now the least recently used
+	require.NoError(t, err)
+	_, err = cache.Get(code('c'))
+	require.NoError(t, err)
+	assert.Equal(t, 2, cache.Len())
+
+	again, err := cache.Get(code('a'))
+	require.NoError(t, err)
+	assert.Same(t, a, again)
+	assert.Equal(t, 2, cache.Len())
+	_, found := cache.modules[codeHash(code('b'))]
+	assert.False(t, found, "least recently used module evicted")
+}
+
+func TestModuleCache_Disk(t *testing.T) {
+	dir := t.TempDir()
+	engine := wasmtime.NewEngine()
+
+	_, err := NewModuleCache(engine, dir, 0).Get(noopModuleCode)
+	require.NoError(t, err)
+
+	artifact := filepath.Join(dir, codeHash(noopModuleCode)+".cwasm")
+	require.FileExists(t, artifact)
+
+	module, err := NewModuleCache(engine, dir, 0).Get(noopModuleCode)
+	require.NoError(t, err)
+	require.Len(t, module.Exports(), 1)
+	assert.Equal(t, "noop", module.Exports()[0].Name())
+
+	// the artifact is mapped in memory by the loaded module, replace it instead of truncating it
+	require.NoError(t, os.Remove(artifact))
+	require.NoError(t, os.WriteFile(artifact, []byte("corrupted"), 0644))
+	module, err = NewModuleCache(engine, dir, 0).Get(noopModuleCode)
+	require.NoError(t, err)
+	require.Len(t, module.Exports(), 1)
+}
@@ -0,0 +1,210 @@
+package wasm
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/streamingfast/dstore"
+	"go.uber.org/zap"
+)
+
+// DefaultExtensionCacheBlocks is the number of blocks whose extension results
+// are kept in memory by `ExtensionCache`.
+const DefaultExtensionCacheBlocks = 1000
+
+// extensionCacheFlushDelay batches the results of a block: they are written
+// that long after the first new result, once its calls are likely done.
+var extensionCacheFlushDelay = time.Second
+
+// ExtensionCache persists the results of WASM extension calls, so that
+// processing a block again (back-processing, retries, replays) returns the
+// same results without calling the extension, and its upstream, again.
+//
+// Results are keyed by the extension's namespace and function, the block id
+// and the input bytes. The results of a block are held in memory, loaded from
+// a single object per block on first use, and new ones are merged back into it
+// in the background, the stored results winning. Failing calls are not cached,
+// and failing to read or write the cache only falls back to calling the
+// extension: a failed load is retried on the next use of the block, whose new
+// results are not written until it succeeds.
+type ExtensionCache struct {
+	store dstore.Store
+
+	lock      sync.Mutex
+	maxBlocks int
+	blocks    map[string]*list.Element // of *extensionCacheBlock
+	recent    *list.List               // most recently used first
+
+	writes sync.WaitGroup
+}
+
+type extensionCacheBlock struct {
+	id string
+
+	loadLock sync.Mutex // held while loading
+	loaded   bool       // guarded by `lock`
+
+	lock      sync.Mutex
+	results   map[string][]byte
+	scheduled bool
+}
+
+func NewExtensionCache(store dstore.Store) *ExtensionCache {
+	return &ExtensionCache{
+		store:     store,
+		maxBlocks: DefaultExtensionCacheBlocks,
+		blocks:    map[string]*list.Element{},
+		recent:    list.New(),
+	}
+}
+
+func extensionCacheFilename(blockID string) string {
+	return blockID + ".json"
+}
+
+func extensionCacheKey(namespace, name string, input []byte) string {
+	return fmt.Sprintf("%s/%s/%x", namespace, name, sha256.Sum256(input))
+}
+
+func (c *ExtensionCache) get(ctx context.Context, namespace, name, blockID string, input []byte) ([]byte, bool) {
+	b := c.block(ctx, blockID)
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	out, found := b.results[extensionCacheKey(namespace, name, input)]
+	return out, found
+}
+
+func (c *ExtensionCache) put(ctx context.Context, namespace, name, blockID string, input, output []byte) {
+	b := c.block(ctx, blockID)
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.results[extensionCacheKey(namespace, name, input)] = output
+	if b.scheduled {
+		return
+	}
+	b.scheduled = true
+	c.writes.Add(1)
+	time.AfterFunc(extensionCacheFlushDelay, func() {
+		defer c.writes.Done()
+		c.write(b)
+	})
+}
+
+// block returns the results of `blockID`, loading them on first use, or
+// again on the next use after a failed load. Concurrent callers asking for
+// the same block wait on a single load.
+func (c *ExtensionCache) block(ctx context.Context, blockID string) *extensionCacheBlock {
+	c.lock.Lock()
+	var b *extensionCacheBlock
+	if element, found := c.blocks[blockID]; found {
+		c.recent.MoveToFront(element)
+		b = element.Value.(*extensionCacheBlock)
+	} else {
+		b = &extensionCacheBlock{id: blockID, results: map[string][]byte{}}
+		c.blocks[blockID] = c.recent.PushFront(b)
+		for c.recent.Len() > c.maxBlocks {
+			// a pending write still holds the block it writes
+			oldest := c.recent.Remove(c.recent.Back()).(*extensionCacheBlock)
+			delete(c.blocks, oldest.id)
+		}
+	}
+	c.lock.Unlock()
+
+	b.loadLock.Lock()
+	defer b.loadLock.Unlock()
+	if b.isLoaded() {
+		return b
+	}
+
+	stored, err := c.read(ctx, b.id)
+	if err != nil {
+		zlog.Warn("failed loading wasm extension cache", zap.String("block_id", b.id), zap.Error(err))
+		return b
+	}
+	b.lock.Lock()
+	b.merge(stored)
+	b.loaded = true
+	b.lock.Unlock()
+	return b
+}
+
+func (b *extensionCacheBlock) isLoaded() bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.loaded
+}
+
+// merge adds the `stored` results, replacing the ones computed meanwhile.
+// The lock must be held.
+func (b *extensionCacheBlock) merge(stored map[string][]byte) {
+	for key, value := range stored {
+		b.results[key] = value
+	}
+}
+
+// read returns the stored results of `blockID`, none when it has no object.
+func (c *ExtensionCache) read(ctx context.Context, blockID string) (map[string][]byte, error) {
+	filename := extensionCacheFilename(blockID)
+	reader, err := c.store.OpenObject(ctx, filename)
+	if err != nil {
+		if errors.Is(err, dstore.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("opening %q: %w", filename, err)
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("reading %q: %w", filename, err)
+	}
+	results := map[string][]byte{}
+	if err := json.Unmarshal(data, &results); err != nil {
+		return nil, fmt.Errorf("decoding %q: %w", filename, err)
+	}
+	return results, nil
+}
+
+// write merges the results of `b` into its stored object, which may have
+// been written since `b` was loaded, by another worker or by the same block
+// evicted and loaded again. Blocks which failed to load are not written, their
+// stored results would be lost.
+func (c *ExtensionCache) write(b *extensionCacheBlock) {
+	filename := extensionCacheFilename(b.id)
+	b.lock.Lock()
+	b.scheduled = false
+	loaded := b.loaded
+	b.lock.Unlock()
+	if !loaded {
+		zlog.Debug("skipping write of wasm extension cache never loaded", zap.String("filename", filename))
+		return
+	}
+
+	// the request adding the results may be over already
+	stored, err := c.read(context.Background(), b.id)
+	if err != nil {
+		zlog.Warn("failed loading wasm extension cache before writing it", zap.String("filename", filename), zap.Error(err))
+		return
+	}
+	b.lock.Lock()
+	b.merge(stored)
+	data, err := json.Marshal(b.results)
+	b.lock.Unlock()
+	if err != nil {
+		zlog.Warn("failed encoding wasm extension cache", zap.String("filename", filename), zap.Error(err))
+		return
+	}
+	if err := c.store.WriteObject(context.Background(), filename, bytes.NewReader(data)); err != nil {
+		zlog.Warn("failed writing wasm extension cache", zap.String("filename", filename), zap.Error(err))
+	}
+}
@@ -0,0 +1,173 @@
+package wasm
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/bytecodealliance/wasmtime-go"
+	"github.com/streamingfast/dstore"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// calls `rpc::call` with "input" and outputs its result
+const extensionModuleText = `
+(module
+  (import "rpc" "call" (func $call (param i32 i32 i32)))
+  (import "env" "output" (func $output (param i32 i32)))
+  (memory (export "memory") 1)
+  (global $next (mut i32) (i32.const 1024))
+  (data (i32.const 0) "input")
+  (func (export "alloc") (param $size i32) (result i32)
+    (local $ptr i32)
+    (local.set $ptr (global.get $next))
+    (global.set $next (i32.add (global.get $next) (local.get $size)))
+    (local.get $ptr))
+  (func (export "dealloc") (param i32 i32))
+  (func (export "map_test")
+    (call $call (i32.const 0) (i32.const 5) (i32.const 16))
+    (call $output (i32.load (i32.const 16)) (i32.load (i32.const 20))))
+)`
+
+type testExtensions map[string]map[string]WASMExtension
+
+func (e testExtensions) WASMExtensions() map[string]map[string]WASMExtension { return e }
+
+func TestExtensionCache(t *testing.T) {
+	code, err := wasmtime.Wat2Wasm(extensionModuleText)
+	require.NoError(t, err)
+
+	cacheStore, err := dstore.NewStore("file://"+t.TempDir(), "", "", false)
+	require.NoError(t, err)
+
+	calls := 0
+	extensions := testExtensions{"rpc": {"call": func(ctx context.Context, request *pbsubstreams.Request, clock *pbsubstreams.Clock, in []byte) ([]byte, error) {
+		calls++
+		return []byte(string(in) + "@" + clock.Id), nil
+	}}}
+
+	run := func(cache *ExtensionCache, clock *pbsubstreams.Clock) []byte {
+		module, err := NewRuntime([]WASMExtensioner{extensions}, WithExtensionCache(cache)).NewModule(context.Background(), nil, BinaryTypeRustV1, code, "map_test", "", "map_test")
+		require.NoError(t, err)
+		instance, err := module.NewInstance(clock, nil)
+		require.NoError(t, err)
+		require.NoError(t, instance.Execute())
+		return instance.Output()
+	}
+
+	cache := NewExtensionCache(cacheStore)
+	assert.Equal(t, []byte("input@a"), run(cache, &pbsubstreams.Clock{Number: 1, Id: "a"}))
+	assert.Equal(t, 1, calls)
+
+	assert.Equal(t, []byte("input@a"), run(cache, &pbsubstreams.Clock{Number: 1, Id: "a"}))
+	assert.Equal(t, 1, calls)
+
+	assert.Equal(t, []byte("input@b"), run(cache, &pbsubstreams.Clock{Number: 1, Id: "b"}))
+	assert.Equal(t, 2, calls)
+
+	cache.writes.Wait()
+	files, err := cacheStore.ListFiles(context.Background(), "", 10)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"a.json", "b.json"}, files)
+
+	// a new cache, as would a restarted process, reads the written results
+	assert.Equal(t, []byte("input@a"), run(NewExtensionCache(cacheStore), &pbsubstreams.Clock{Number: 1, Id: "a"}))
+	assert.Equal(t, 2, calls)
+}
+
+func TestExtensionCache_eviction(t *testing.T) {
+	cacheStore, err := dstore.NewStore("file://"+t.TempDir(), "", "", false)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	cache := NewExtensionCache(cacheStore)
+	cache.maxBlocks = 2
+	cache.put(ctx, "rpc", "call", "a", []byte("in"), []byte("out@a"))
+	cache.put(ctx, "rpc", "call", "b", []byte("in"), []byte("out@b"))
+	cache.put(ctx, "rpc", "call", "c", []byte("in"), []byte("out@c"))
+	assert.Equal(t, 2, cache.recent.Len())
+	assert.NotContains(t, cache.blocks, "a")
+
+	// evicted before its write, the block is still written and read back
+	cache.writes.Wait()
+	out, found := cache.get(ctx, "rpc", "call", "a", []byte("in"))
+	require.True(t, found)
+	assert.Equal(t, []byte("out@a"), out)
+	_, found = cache.get(ctx, "rpc", "other", "a", []byte("in"))
+	assert.False(t, found)
+}
+
+// failingStore fails opening objects while `failing` is set
+type failingStore struct {
+	dstore.Store
+	failing bool
+}
+
+func (s *failingStore) OpenObject(ctx context.Context, name string) (io.ReadCloser, error) {
+	if s.failing {
+		return nil, errors.New("unavailable")
+	}
+	return s.Store.OpenObject(ctx, name)
+}
+
+func TestExtensionCache_failedLoad(t *testing.T) {
+	fileStore, err := dstore.NewStore("file://"+t.TempDir(), "", "", false)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	stored := NewExtensionCache(fileStore)
+	stored.put(ctx, "rpc", "call", "a", []byte("in"), []byte("out"))
+	stored.writes.Wait()
+
+	cacheStore := &failingStore{Store: fileStore, failing: true}
+	cache := NewExtensionCache(cacheStore)
+	_, found := cache.get(ctx, "rpc", "call", "a", []byte("in"))
+	assert.False(t, found)
+
+	// the block is not written, which would drop its stored results
+	cache.put(ctx, "rpc", "call", "a", []byte("other"), []byte("other out"))
+	cache.writes.Wait()
+	_, found = NewExtensionCache(fileStore).get(ctx, "rpc", "call", "a", []byte("other"))
+	assert.False(t, found)
+
+	// the load is retried, and writes resume once it succeeds
+	cacheStore.failing = false
+	out, found := cache.get(ctx, "rpc", "call", "a", []byte("in"))
+	require.True(t, found)
+	assert.Equal(t, []byte("out"), out)
+
+	cache.put(ctx, "rpc", "call", "a", []byte("more"), []byte("more out"))
+	cache.writes.Wait()
+	reloaded := NewExtensionCache(fileStore)
+	for input, output := range map[string]string{"in": "out", "other": "other out", "more": "more out"} {
+		out, found := reloaded.get(ctx, "rpc", "call", "a", []byte(input))
+		require.True(t, found, input)
+		assert.Equal(t, []byte(output), out)
+	}
+}
+
+func TestExtensionCache_mergedWrites(t *testing.T) {
+	cacheStore, err := dstore.NewStore("file://"+t.TempDir(), "", "", false)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// another worker, or the same block evicted and loaded again, writes
+	// after the block was loaded
+	first, second := NewExtensionCache(cacheStore), NewExtensionCache(cacheStore)
+	_, found := second.get(ctx, "rpc", "call", "a", []byte("first"))
+	require.False(t, found)
+	first.put(ctx, "rpc", "call", "a", []byte("first"), []byte("first out"))
+	first.writes.Wait()
+	second.put(ctx, "rpc", "call", "a", []byte("second"), []byte("second out"))
+	second.writes.Wait()
+
+	reloaded := NewExtensionCache(cacheStore)
+	for _, input := range []string{"first", "second"} {
+		out, found := reloaded.get(ctx, "rpc", "call", "a", []byte(input))
+		require.True(t, found, input)
+		assert.Equal(t, []byte(input+" out"), out)
+	}
+}
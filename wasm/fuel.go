@@ -0,0 +1,51 @@
+package wasm
+
+import (
+	"fmt"
+	"math"
+)
+
+// unmeteredFuel is the fuel left in a module's store outside of metered
+// executions, so host-driven calls like heap allocations never run dry.
+const unmeteredFuel uint64 = math.MaxInt64 >> 1
+
+type OutOfFuelError struct {
+	Limit uint64
+}
+
+func (e *OutOfFuelError) Error() string {
+	return fmt.Sprintf("out of fuel, execution consumed its whole budget of %d units", e.Limit)
+}
+
+// setFuel adjusts the fuel remaining in the module's store to exactly `amount`.
+func (m *Module) setFuel(amount uint64) error {
+	remaining := m.remainingFuel()
+	if remaining < amount {
+		if err := m.wasmStore.AddFuel(amount - remaining); err != nil {
+			return fmt.Errorf("adding fuel: %w", err)
+		}
+		m.fuelAdded += amount - remaining
+		return nil
+	}
+	if remaining > amount {
+		if _, err := m.wasmStore.ConsumeFuel(remaining - amount); err != nil {
+			return fmt.Errorf("consuming fuel: %w", err)
+		}
+	}
+	return nil
+}
+
+// remainingFuel is computed from what was added since the store, once
+// empty, refuses to report it.
+func (m *Module) remainingFuel() uint64 {
+	consumed := m.fuelConsumed()
+	if consumed >= m.fuelAdded {
+		return 0
+	}
+	return m.fuelAdded - consumed
+}
+
+func (m *Module) fuelConsumed() uint64 {
+	consumed, _ := m.wasmStore.FuelConsumed()
+	return consumed
+}
@@ -35,6 +35,9 @@ func (h *Heap) Write(bytes []byte, from string) (int32, error) {
 
 func (h *Heap) WriteAndTrack(bytes []byte, track bool, from string) (int32, error) {
 	size := len(bytes)
+	if h.allocator == nil {
+		return 0, fmt.Errorf("allocating memory for size %d: module does not export %q", size, "alloc")
+	}
 	results, err := h.allocator.Call(h.store, int32(size))
 	if err != nil {
 		return 0, fmt.Errorf("allocating memory for size %d:%w", size, err)
@@ -54,6 +57,10 @@ func (h *Heap) WriteAtPtr(bytes []byte, ptr int32, from string) (int32, error) {
 }
 
 func (h *Heap) Clear() error {
+	if h.dealloc == nil {
+		h.allocations = nil
+		return nil
+	}
 	sort.Slice(h.allocations, func(i, j int) bool {
 		return h.allocations[i].ptr < h.allocations[j].ptr
 	})
@@ -2,11 +2,15 @@ package wasm
 
 import (
 	"encoding/binary"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/bytecodealliance/wasmtime-go"
 	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
 	"github.com/streamingfast/substreams/store"
+	"go.uber.org/zap"
 )
 
 type Instance struct {
@@ -23,14 +27,29 @@ type Instance struct {
 	panicError  *PanicError
 
 	Logs           []string
+	StructuredLogs []*pbsubstreams.ModuleLog
 	LogsByteCount  uint64
 	ExecutionStack []string
 	Module         *Module
 	entrypoint     *wasmtime.Func
+
+	fuelLimit    *uint64
+	FuelConsumed uint64
+
+	// WASI modules pull their value inputs instead of receiving them as arguments
+	inputs   [][]byte
+	stdio    []byte
+	exitCode *int32
+}
+
+// SetFuelLimit bounds the fuel the next execution may consume, the runtime
+// must have been created with `WithFuelMetering`.
+func (i *Instance) SetFuelLimit(limit uint64) {
+	i.fuelLimit = &limit
 }
 
 func (i *Instance) Execute() (err error) {
-	if _, err = i.entrypoint.Call(i.Module.wasmStore, i.args...); err != nil {
+	if err = i.call(i.args...); err != nil {
 		if i.panicError != nil {
 			return i.panicError
 		}
@@ -40,7 +59,7 @@ func (i *Instance) Execute() (err error) {
 }
 
 func (i *Instance) ExecuteWithArgs(args ...interface{}) (err error) {
-	if _, err = i.entrypoint.Call(i.Module.wasmStore, args...); err != nil {
+	if err = i.call(args...); err != nil {
 		if i.panicError != nil {
 			return i.panicError
 		}
@@ -49,6 +68,87 @@ func (i *Instance) ExecuteWithArgs(args ...interface{}) (err error) {
 	return nil
 }
 
+func (i *Instance) call(args ...interface{}) error {
+	err := i.meteredCall(args...)
+	if i.Module.binaryType == BinaryTypeWASIV1 {
+		err = i.wasiResult(err)
+	}
+	i.Module.updateMemoryUsage()
+	reachedMemoryLimit := err != nil && i.reachedMemoryLimit(err)
+
+	var trap *wasmtime.Trap
+	if errors.As(err, &trap) {
+		i.recordTrap(trap, !reachedMemoryLimit)
+	}
+
+	if reachedMemoryLimit {
+		return &MemoryLimitError{Limit: i.Module.runtime.maxMemoryPages() * wasmPageSize, Cause: err}
+	}
+	return err
+}
+
+// recordTrap pushes the symbolized backtrace of the trap on the execution
+// stack and attaches it to the panic registered by the module. Without one,
+// the trap itself becomes the panic when `asPanic` is set, located at its
+// innermost frame having debug info.
+func (i *Instance) recordTrap(trap *wasmtime.Trap, asPanic bool) {
+	symbols := i.Module.symbolTable()
+
+	var frames []string
+	var located *frameSymbol
+	for idx, frame := range trap.Frames() {
+		symbol := symbols.symbolize(frame)
+		if located == nil && symbol.file != "" {
+			located = &symbol
+		}
+		frames = append(frames, symbol.String())
+		i.PushExecutionStack(fmt.Sprintf("backtrace: #%d %s", idx, symbol))
+	}
+
+	if i.panicError != nil {
+		i.panicError.frames = frames
+		return
+	}
+	if !asPanic {
+		return
+	}
+
+	i.panicError = &PanicError{message: trapMessage(trap), frames: frames}
+	if located != nil {
+		i.panicError.filename = located.file
+		i.panicError.lineNumber = located.line
+		i.panicError.columnNumber = located.column
+	}
+}
+
+func (i *Instance) meteredCall(args ...interface{}) error {
+	if i.fuelLimit == nil {
+		_, err := i.entrypoint.Call(i.Module.wasmStore, args...)
+		return err
+	}
+
+	if !i.Module.runtime.fuelMetering {
+		return fmt.Errorf("fuel limit set but fuel metering is not enabled on the runtime")
+	}
+	if err := i.Module.setFuel(*i.fuelLimit); err != nil {
+		return fmt.Errorf("setting fuel limit: %w", err)
+	}
+
+	consumedBefore := i.Module.fuelConsumed()
+	_, callErr := i.entrypoint.Call(i.Module.wasmStore, args...)
+	i.FuelConsumed = i.Module.fuelConsumed() - consumedBefore
+
+	remaining := i.Module.remainingFuel()
+	if err := i.Module.setFuel(unmeteredFuel); err != nil {
+		return fmt.Errorf("resetting fuel: %w", err)
+	}
+
+	if callErr != nil && remaining == 0 {
+		return &OutOfFuelError{Limit: *i.fuelLimit}
+	}
+	return callErr
+}
+
 func (i *Instance) WriteOutputToHeap(outputPtr int32, value []byte, from string) error {
 	valuePtr, err := i.Module.Heap.WriteAndTrack(value, false, from+":WriteOutputToHeap1")
 	if err != nil {
@@ -84,6 +184,48 @@ func (i *Instance) ReachedLogsMaxByteCount() bool {
 	return i.LogsByteCount >= maxLogByteCount
 }
 
+func (i *Instance) logLevelEnabled(level pbsubstreams.LogLevel) bool {
+	return level >= i.Module.minLogLevel
+}
+
+func (i *Instance) appendLog(level pbsubstreams.LogLevel, message string, fields []*pbsubstreams.LogField) {
+	if !i.logLevelEnabled(level) {
+		return
+	}
+
+	text := renderLog(message, fields)
+	if tracer.Enabled() {
+		zlog.Debug(text, zap.String("module_name", i.Module.name), zap.String("wasm_file", i.Module.name), zap.Stringer("level", level))
+	}
+
+	// len(<string>) in Go count number of bytes and not characters, so we are good here
+	i.LogsByteCount += uint64(len(text))
+	if !i.ReachedLogsMaxByteCount() {
+		i.Logs = append(i.Logs, text)
+		i.StructuredLogs = append(i.StructuredLogs, &pbsubstreams.ModuleLog{Level: level, Message: message, Fields: fields})
+		i.PushExecutionStack(fmt.Sprintf("log: %s", text))
+	}
+}
+
+// renderLog appends the fields to the message as `key=value`, quoting values
+// containing spaces or quotes.
+func renderLog(message string, fields []*pbsubstreams.LogField) string {
+	if len(fields) == 0 {
+		return message
+	}
+
+	var b strings.Builder
+	b.WriteString(message)
+	for _, field := range fields {
+		value := field.Value
+		if value == "" || strings.ContainsAny(value, " \t\n\"=") {
+			value = strconv.Quote(value)
+		}
+		fmt.Fprintf(&b, " %s=%s", field.Key, value)
+	}
+	return b.String()
+}
+
 func (i *Instance) PushExecutionStack(event string) {
 	i.ExecutionStack = append(i.ExecutionStack, event)
 }
@@ -0,0 +1,104 @@
+package wasm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/bytecodealliance/wasmtime-go"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// logs "debug" at debug level, "println" through println, then "done" at
+// warn level with the fields found at offset 64
+const logModuleText = `
+(module
+  (import "logger" "println" (func $println (param i32 i32)))
+  (import "logger" "log" (func $log (param i32 i32 i32 i32 i32)))
+  (memory (export "memory") 1)
+  (data (i32.const 0) "debug")
+  (data (i32.const 16) "println")
+  (data (i32.const 32) "done")
+  (data (i32.const 64) "%s")
+  (func (export "map_test")
+    (call $log (i32.const 2) (i32.const 0) (i32.const 5) (i32.const 0) (i32.const 0))
+    (call $println (i32.const 16) (i32.const 7))
+    (call $log (i32.const 4) (i32.const 32) (i32.const 4) (i32.const 64) (i32.const %d)))
+)`
+
+func TestInstance_log(t *testing.T) {
+	fields := []*pbsubstreams.LogField{{Key: "block", Value: "10"}, {Key: "hash", Value: "ab cd"}}
+	encodedFields, err := proto.Marshal(&pbsubstreams.LogFields{Fields: fields})
+	require.NoError(t, err)
+
+	var escaped strings.Builder
+	for _, b := range encodedFields {
+		fmt.Fprintf(&escaped, "\\%02x", b)
+	}
+	code, err := wasmtime.Wat2Wasm(fmt.Sprintf(logModuleText, escaped.String(), len(encodedFields)))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		minLogLevel  pbsubstreams.LogLevel
+		expectedLogs []string
+	}{
+		{"all levels", pbsubstreams.LogLevel_LOG_LEVEL_UNSET, []string{"debug", "println", `done block=10 hash="ab cd"`}},
+		{"info and above", pbsubstreams.LogLevel_LOG_LEVEL_INFO, []string{"println", `done block=10 hash="ab cd"`}},
+		{"warn and above", pbsubstreams.LogLevel_LOG_LEVEL_WARN, []string{`done block=10 hash="ab cd"`}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request := &pbsubstreams.Request{MinLogLevel: test.minLogLevel}
+			module, err := NewRuntime(nil).NewModule(context.Background(), request, BinaryTypeWASIV1, code, "map_test", "", "map_test")
+			require.NoError(t, err)
+
+			instance, err := module.NewInstance(&pbsubstreams.Clock{Number: 10}, nil)
+			require.NoError(t, err)
+			require.NoError(t, instance.Execute())
+
+			assert.Equal(t, test.expectedLogs, instance.Logs)
+			require.Len(t, instance.StructuredLogs, len(test.expectedLogs))
+
+			last := instance.StructuredLogs[len(instance.StructuredLogs)-1]
+			assert.Equal(t, pbsubstreams.LogLevel_LOG_LEVEL_WARN, last.Level)
+			assert.Equal(t, "done", last.Message)
+			assert.True(t, proto.Equal(&pbsubstreams.LogFields{Fields: fields}, &pbsubstreams.LogFields{Fields: last.Fields}))
+
+			var logsByteCount uint64
+			for _, log := range test.expectedLogs {
+				logsByteCount += uint64(len(log))
+			}
+			assert.Equal(t, logsByteCount, instance.LogsByteCount)
+		})
+	}
+}
+
+func TestInstance_logInvalidLevel(t *testing.T) {
+	for _, level := range []int32{0, 9} {
+		t.Run(fmt.Sprintf("level %d", level), func(t *testing.T) {
+			code, err := wasmtime.Wat2Wasm(fmt.Sprintf(`(module
+  (import "logger" "log" (func $log (param i32 i32 i32 i32 i32)))
+  (memory (export "memory") 1)
+  (func (export "map_test")
+    (call $log (i32.const %d) (i32.const 0) (i32.const 0) (i32.const 0) (i32.const 0))))`, level))
+			require.NoError(t, err)
+
+			// validated even below the minimum level, which would skip the log
+			request := &pbsubstreams.Request{MinLogLevel: pbsubstreams.LogLevel_LOG_LEVEL_ERROR}
+			module, err := NewRuntime(nil).NewModule(context.Background(), request, BinaryTypeWASIV1, code, "map_test", "", "map_test")
+			require.NoError(t, err)
+
+			instance, err := module.NewInstance(&pbsubstreams.Clock{Number: 10}, nil)
+			require.NoError(t, err)
+			err = instance.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), fmt.Sprintf("invalid log level %d", level))
+		})
+	}
+}
@@ -0,0 +1,361 @@
+package wasm
+
+import (
+	"bytes"
+	"container/list"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/bytecodealliance/wasmtime-go"
+	"github.com/dustin/go-humanize"
+	"go.uber.org/zap"
+)
+
+const wasmPageSize = 64 * 1024
+
+const (
+	importSectionID = 2
+	memorySectionID = 5
+)
+
+const (
+	importKindFunc   = 0
+	importKindTable  = 1
+	importKindMemory = 2
+	importKindGlobal = 3
+)
+
+type MemoryLimitError struct {
+	Limit uint64
+	Cause error
+}
+
+func (e *MemoryLimitError) Error() string {
+	return fmt.Sprintf("memory limit of %s reached: %s", humanize.IBytes(e.Limit), e.Cause)
+}
+
+func (e *MemoryLimitError) Unwrap() error {
+	return e.Cause
+}
+
+// limitMemory rewrites the memory section and the memory imports of `code`
+// so that its memories declare a maximum of at most `maxPages`. A
+// `memory.grow` past that maximum fails from within the module, whose
+// allocator then traps.
+func limitMemory(code []byte, maxPages uint32) ([]byte, error) {
+	if len(code) < 8 || !bytes.Equal(code[0:4], []byte("\x00asm")) {
+		return nil, fmt.Errorf("invalid wasm binary, bad magic header")
+	}
+
+	out := make([]byte, 0, len(code)+8)
+	out = append(out, code[0:8]...)
+
+	pos := 8
+	for pos < len(code) {
+		sectionID := code[pos]
+		size, n := binary.Uvarint(code[pos+1:])
+		if n <= 0 {
+			return nil, fmt.Errorf("invalid size for section %d at offset %d", sectionID, pos)
+		}
+		start := pos + 1 + n
+		end := start + int(size)
+		if end > len(code) {
+			return nil, fmt.Errorf("section %d at offset %d overflows binary", sectionID, pos)
+		}
+
+		var content []byte
+		var err error
+		switch sectionID {
+		case importSectionID:
+			content, err = limitImportSection(code[start:end], maxPages)
+		case memorySectionID:
+			content, err = limitMemorySection(code[start:end], maxPages)
+		default:
+			out = append(out, code[pos:end]...)
+			pos = end
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, sectionID)
+		out = appendUvarint(out, uint64(len(content)))
+		out = append(out, content...)
+		pos = end
+	}
+
+	return out, nil
+}
+
+func limitImportSection(section []byte, maxPages uint32) ([]byte, error) {
+	count, n := binary.Uvarint(section)
+	if n <= 0 {
+		return nil, fmt.Errorf("invalid import count")
+	}
+	pos := n
+
+	out := appendUvarint(nil, count)
+	for i := uint64(0); i < count; i++ {
+		entryStart := pos
+		for j := 0; j < 2; j++ {
+			length, n := binary.Uvarint(section[pos:])
+			if n <= 0 || pos+n+int(length) > len(section) {
+				return nil, fmt.Errorf("import %d: invalid name", i)
+			}
+			pos += n + int(length)
+		}
+		if pos >= len(section) {
+			return nil, fmt.Errorf("import %d: truncated description", i)
+		}
+		kind := section[pos]
+		pos++
+
+		if kind == importKindMemory {
+			out = append(out, section[entryStart:pos]...)
+			limits, read, err := limitMemoryType(section[pos:], maxPages)
+			if err != nil {
+				return nil, fmt.Errorf("imported memory %d: %w", i, err)
+			}
+			out = append(out, limits...)
+			pos += read
+			continue
+		}
+
+		read, err := importDescriptionSize(kind, section[pos:])
+		if err != nil {
+			return nil, fmt.Errorf("import %d: %w", i, err)
+		}
+		pos += read
+		out = append(out, section[entryStart:pos]...)
+	}
+
+	if pos != len(section) {
+		return nil, fmt.Errorf("unexpected trailing bytes in import section")
+	}
+	return out, nil
+}
+
+// importDescriptionSize returns the size of the description of an import of
+// `kind`, other than a memory, at the start of `desc`.
+func importDescriptionSize(kind byte, desc []byte) (int, error) {
+	switch kind {
+	case importKindFunc:
+		_, n := binary.Uvarint(desc)
+		if n <= 0 {
+			return 0, fmt.Errorf("invalid function type index")
+		}
+		return n, nil
+	case importKindTable:
+		if len(desc) < 2 {
+			return 0, fmt.Errorf("truncated table type")
+		}
+		_, n := binary.Uvarint(desc[2:])
+		if n <= 0 {
+			return 0, fmt.Errorf("invalid table minimum")
+		}
+		size := 2 + n
+		if desc[1]&1 == 1 {
+			_, n := binary.Uvarint(desc[size:])
+			if n <= 0 {
+				return 0, fmt.Errorf("invalid table maximum")
+			}
+			size += n
+		}
+		return size, nil
+	case importKindGlobal:
+		if len(desc) < 2 {
+			return 0, fmt.Errorf("truncated global type")
+		}
+		return 2, nil
+	default:
+		return 0, fmt.Errorf("unsupported import kind %d", kind)
+	}
+}
+
+func limitMemorySection(section []byte, maxPages uint32) ([]byte, error) {
+	count, n := binary.Uvarint(section)
+	if n <= 0 {
+		return nil, fmt.Errorf("invalid memory count")
+	}
+	pos := n
+
+	out := appendUvarint(nil, count)
+	for i := uint64(0); i < count; i++ {
+		limits, read, err := limitMemoryType(section[pos:], maxPages)
+		if err != nil {
+			return nil, fmt.Errorf("memory %d: %w", i, err)
+		}
+		out = append(out, limits...)
+		pos += read
+	}
+
+	if pos != len(section) {
+		return nil, fmt.Errorf("unexpected trailing bytes in memory section")
+	}
+	return out, nil
+}
+
+// limitMemoryType re-encodes the memory limits at the start of `limits` with
+// a maximum of at most `maxPages`, returning the number of bytes read.
+func limitMemoryType(limits []byte, maxPages uint32) ([]byte, int, error) {
+	if len(limits) == 0 {
+		return nil, 0, fmt.Errorf("truncated limits")
+	}
+	flags := limits[0]
+	pos := 1
+	if flags > 1 {
+		return nil, 0, fmt.Errorf("shared or 64-bit memories are not supported")
+	}
+
+	min, n := binary.Uvarint(limits[pos:])
+	if n <= 0 {
+		return nil, 0, fmt.Errorf("invalid minimum")
+	}
+	pos += n
+	if min > uint64(maxPages) {
+		return nil, 0, fmt.Errorf("module requires %s of memory initially, above the limit of %s", humanize.IBytes(min*wasmPageSize), humanize.IBytes(uint64(maxPages)*wasmPageSize))
+	}
+
+	max := uint64(maxPages)
+	if flags == 1 {
+		declaredMax, n := binary.Uvarint(limits[pos:])
+		if n <= 0 {
+			return nil, 0, fmt.Errorf("invalid maximum")
+		}
+		pos += n
+		if declaredMax < max {
+			max = declaredMax
+		}
+	}
+
+	out := []byte{1}
+	out = appendUvarint(out, min)
+	out = appendUvarint(out, max)
+	return out, pos, nil
+}
+
+func appendUvarint(buf []byte, value uint64) []byte {
+	var tmp [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(tmp[:], value)
+	return append(buf, tmp[:n]...)
+}
+
+// MemorySize returns the current size in bytes of the module's linear memory.
+func (m *Module) MemorySize() uint64 {
+	return uint64(m.Heap.memory.DataSize(m.wasmStore))
+}
+
+// PeakMemorySize returns the largest linear memory size in bytes observed, in
+// this process, after an execution of a module with this module's hash.
+func (m *Module) PeakMemorySize() uint64 {
+	return m.runtime.memoryPeaks.get(m.hash)
+}
+
+func (m *Module) updateMemoryUsage() {
+	size := m.MemorySize()
+
+	r := m.runtime
+	if !r.memoryPeaks.update(m.hash, size) {
+		return
+	}
+
+	zlog.Info("new wasm module memory peak",
+		zap.String("module_name", m.name),
+		zap.String("module_hash", m.hash),
+		zap.String("memory_size", humanize.IBytes(size)),
+		zap.Uint64("memory_limit", r.maxMemory),
+	)
+}
+
+// memoryPeaks keeps the memory peak of the modules by hash, for the
+// `maxModules` most recently executed ones, like `ModuleCache` does for
+// compiled modules, so it does not grow with every module served.
+type memoryPeaks struct {
+	lock       sync.Mutex
+	maxModules int
+	peaks      map[string]*list.Element // of *memoryPeak
+	recent     *list.List               // most recently used first
+}
+
+type memoryPeak struct {
+	hash string
+	size uint64
+}
+
+// newMemoryPeaks creates peaks kept for up to `maxModules` modules,
+// `DefaultModuleCacheSize` when zero.
+func newMemoryPeaks(maxModules int) *memoryPeaks {
+	if maxModules <= 0 {
+		maxModules = DefaultModuleCacheSize
+	}
+	return &memoryPeaks{
+		maxModules: maxModules,
+		peaks:      map[string]*list.Element{},
+		recent:     list.New(),
+	}
+}
+
+func (p *memoryPeaks) get(hash string) uint64 {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if element, found := p.peaks[hash]; found {
+		p.recent.MoveToFront(element)
+		return element.Value.(*memoryPeak).size
+	}
+	return 0
+}
+
+// update records `size` for `hash`, telling if it is a new peak.
+func (p *memoryPeaks) update(hash string, size uint64) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	element, found := p.peaks[hash]
+	if !found {
+		element = p.recent.PushFront(&memoryPeak{hash: hash})
+		p.peaks[hash] = element
+		for p.recent.Len() > p.maxModules {
+			oldest := p.recent.Remove(p.recent.Back()).(*memoryPeak)
+			delete(p.peaks, oldest.hash)
+		}
+	} else {
+		p.recent.MoveToFront(element)
+	}
+
+	peak := element.Value.(*memoryPeak)
+	if size <= peak.size {
+		return false
+	}
+	peak.size = size
+	return true
+}
+
+// len returns the number of modules whose peak is kept.
+func (p *memoryPeaks) len() int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.recent.Len()
+}
+
+// reachedMemoryLimit tells whether the execution failing with `err` aborted
+// on an allocation failure at the memory limit: the module trapped on
+// `unreachable`, as allocators do when `memory.grow` fails, without
+// registering a panic, and its memory cannot grow past the limit anymore.
+func (i *Instance) reachedMemoryLimit(err error) bool {
+	r := i.Module.runtime
+	if r.maxMemory == 0 || i.panicError != nil {
+		return false
+	}
+
+	var trap *wasmtime.Trap
+	if !errors.As(err, &trap) {
+		return false
+	}
+	code := trap.Code()
+	if code == nil || *code != wasmtime.UnreachableCodeReached {
+		return false
+	}
+	return i.Module.MemorySize()/wasmPageSize >= r.maxMemoryPages()
+}
@@ -0,0 +1,178 @@
+package wasm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bytecodealliance/wasmtime-go"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// (module (memory (export "memory") <limits>))
+func memoryModuleCode(limits ...byte) []byte {
+	code := []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+	code = append(code, memorySectionID, byte(len(limits)+1), 0x01)
+	code = append(code, limits...)
+	code = append(code, 0x07, 0x0a, 0x01, 0x06, 'm', 'e', 'm', 'o', 'r', 'y', 0x02, 0x00)
+	return code
+}
+
+func Test_limitMemory(t *testing.T) {
+	tests := []struct {
+		name        string
+		code        []byte
+		maxPages    uint32
+		expectedMax uint64
+		expectedErr string
+	}{
+		{"no declared max", memoryModuleCode(0x00, 0x01), 16, 16, ""},
+		{"declared max above limit", memoryModuleCode(0x01, 0x01, 0x20), 16, 16, ""},
+		{"declared max below limit", memoryModuleCode(0x01, 0x01, 0x04), 16, 4, ""},
+		{"multi-byte limit", memoryModuleCode(0x00, 0x01), 300, 300, ""},
+		{"minimum above limit", memoryModuleCode(0x00, 0x11), 16, 0, "module requires 1.1 MiB of memory initially, above the limit of 1.0 MiB"},
+		{"shared memory", memoryModuleCode(0x03, 0x01, 0x04), 16, 0, "shared or 64-bit memories are not supported"},
+		{"not wasm", []byte("not wasm"), 16, 0, "bad magic header"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			code, err := limitMemory(test.code, test.maxPages)
+			if test.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			module, err := wasmtime.NewModule(wasmtime.NewEngine(), code)
+			require.NoError(t, err)
+			require.Len(t, module.Exports(), 1)
+
+			hasMax, max := module.Exports()[0].Type().MemoryType().Maximum()
+			assert.True(t, hasMax)
+			assert.Equal(t, test.expectedMax, max)
+		})
+	}
+}
+
+func Test_limitMemory_imported(t *testing.T) {
+	code, err := wasmtime.Wat2Wasm(`(module
+  (import "env" "f" (func))
+  (import "env" "table" (table 1 2 funcref))
+  (import "env" "memory" (memory 1))
+  (import "env" "global" (global i32)))`)
+	require.NoError(t, err)
+
+	code, err = limitMemory(code, 16)
+	require.NoError(t, err)
+
+	module, err := wasmtime.NewModule(wasmtime.NewEngine(), code)
+	require.NoError(t, err)
+	require.Len(t, module.Imports(), 4)
+
+	hasMax, max := module.Imports()[2].Type().MemoryType().Maximum()
+	assert.True(t, hasMax)
+	assert.Equal(t, uint64(16), max)
+}
+
+// grows the memory by `pages` one page at a time, then traps when `trap` is
+// set. A failed growth aborts like an allocator does, after registering a
+// panic when `panics` is set.
+const growModuleText = `
+(module
+  (import "env" "register_panic" (func $register_panic (param i32 i32 i32 i32 i32 i32)))
+  (memory (export "memory") 1)
+  (func (export "map_test") (param $pages i32) (param $trap i32) (param $panics i32)
+    (block $done
+      (loop $grow
+        (br_if $done (i32.eqz (local.get $pages)))
+        (if (i32.eq (memory.grow (i32.const 1)) (i32.const -1))
+          (then
+            (if (local.get $panics)
+              (then (call $register_panic (i32.const 0) (i32.const 0) (i32.const 0) (i32.const 0) (i32.const 0) (i32.const 0))))
+            (unreachable)))
+        (local.set $pages (i32.sub (local.get $pages) (i32.const 1)))
+        (br $grow)))
+    (if (local.get $trap) (then (unreachable))))
+)`
+
+func TestInstance_memoryLimit(t *testing.T) {
+	code, err := wasmtime.Wat2Wasm(growModuleText)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name               string
+		pages              int32
+		trap               int32
+		panics             int32
+		expectedPeak       uint64
+		expectedLimitError bool
+		expectedPanic      bool
+	}{
+		{"within the limit", 2, 0, 0, 3 * wasmPageSize, false, false},
+		{"exceeds the limit", 10, 0, 0, 4 * wasmPageSize, true, false},
+		{"traps below the limit", 1, 1, 0, 2 * wasmPageSize, false, true},
+		{"panics at the limit", 10, 0, 1, 4 * wasmPageSize, false, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			runtime := NewRuntime(nil, WithMaxMemory(4*wasmPageSize))
+			module, err := runtime.NewModule(context.Background(), nil, BinaryTypeWASIV1, code, "map_test", "hash", "map_test")
+			require.NoError(t, err)
+
+			instance, err := module.NewInstance(&pbsubstreams.Clock{Number: 10}, nil)
+			require.NoError(t, err)
+			err = instance.ExecuteWithArgs(test.pages, test.trap, test.panics)
+
+			var limitErr *MemoryLimitError
+			assert.Equal(t, test.expectedLimitError, errors.As(err, &limitErr))
+			var panicErr *PanicError
+			assert.Equal(t, test.expectedPanic, errors.As(err, &panicErr))
+			if !test.expectedLimitError && !test.expectedPanic {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expectedPeak, module.PeakMemorySize())
+		})
+	}
+}
+
+func TestModule_PeakMemorySize(t *testing.T) {
+	code, err := wasmtime.Wat2Wasm(growModuleText)
+	require.NoError(t, err)
+	runtime := NewRuntime(nil)
+
+	execute := func(hash string, pages int32) *Module {
+		module, err := runtime.NewModule(context.Background(), nil, BinaryTypeWASIV1, code, "map_test", hash, "map_test")
+		require.NoError(t, err)
+		instance, err := module.NewInstance(&pbsubstreams.Clock{Number: 10}, nil)
+		require.NoError(t, err)
+		require.NoError(t, instance.ExecuteWithArgs(pages, int32(0), int32(0)))
+		return module
+	}
+
+	// modules of the same name in different packages have their own peak
+	a := execute("a", 3)
+	b := execute("b", 0)
+	assert.Equal(t, uint64(4*wasmPageSize), a.PeakMemorySize())
+	assert.Equal(t, uint64(wasmPageSize), b.PeakMemorySize())
+
+	assert.Equal(t, uint64(4*wasmPageSize), execute("a", 1).PeakMemorySize())
+}
+
+func TestMemoryPeaks_evictsLeastRecentlyUsed(t *testing.T) {
+	peaks := newMemoryPeaks(2)
+
+	assert.True(t, peaks.update("a", 10))
+	assert.True(t, peaks.update("b", 20))
+	assert.False(t, peaks.update("a", 5))
+	assert.True(t, peaks.update("c", 30))
+
+	assert.Equal(t, 2, peaks.len())
+	assert.Equal(t, uint64(10), peaks.get("a"))
+	assert.Equal(t, uint64(0), peaks.get("b"), "least recently used evicted")
+	assert.Equal(t, uint64(30), peaks.get("c"))
+}
@@ -4,17 +4,27 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 
 	"github.com/bytecodealliance/wasmtime-go"
 	"github.com/dustin/go-humanize"
 	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
-	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+// Binary types supported by the runtime, see `docs/reference-and-specs/wasi-abi.md`
+// for the ABI expected from `wasm/wasi-v1` modules.
+const (
+	BinaryTypeRustV1 = "wasm/rust-v1"
+	BinaryTypeWASIV1 = "wasm/wasi-v1"
 )
 
 type Module struct {
 	runtime *Runtime
 
-	name string
+	name       string
+	hash       string
+	binaryType string
 
 	wasmCode        []byte
 	CurrentInstance *Instance
@@ -25,30 +35,64 @@ type Module struct {
 	wasmModule      *wasmtime.Module
 	wasmLinker      *wasmtime.Linker
 	Heap            *Heap
+
+	fuelAdded   uint64
+	minLogLevel pbsubstreams.LogLevel
+
+	symbols *symbols
 }
 
-func (r *Runtime) NewModule(ctx context.Context, request *pbsubstreams.Request, wasmCode []byte, name string, entrypoint string) (*Module, error) {
-	engine := wasmtime.NewEngine()
-	linker := wasmtime.NewLinker(engine)
-	store := wasmtime.NewStore(engine)
-	module, err := wasmtime.NewModule(store.Engine, wasmCode)
+func (r *Runtime) NewModule(ctx context.Context, request *pbsubstreams.Request, binaryType string, wasmCode []byte, name string, hash string, entrypoint string) (*Module, error) {
+	if binaryType != BinaryTypeRustV1 && binaryType != BinaryTypeWASIV1 {
+		return nil, fmt.Errorf("unsupported binary type %q", binaryType)
+	}
+
+	if r.maxMemory != 0 {
+		pages := r.maxMemoryPages()
+		if pages == 0 || pages > math.MaxUint32 {
+			return nil, fmt.Errorf("invalid max memory %d, must be between 64 KiB and 4 GiB", r.maxMemory)
+		}
+		limitedCode, err := limitMemory(wasmCode, uint32(pages))
+		if err != nil {
+			return nil, fmt.Errorf("limiting memory of module %q: %w", name, err)
+		}
+		wasmCode = limitedCode
+	}
+
+	linker := wasmtime.NewLinker(r.engine)
+	store := wasmtime.NewStore(r.engine)
+	module, err := r.moduleCache.Get(wasmCode)
 	if err != nil {
 		return nil, fmt.Errorf("creating new module: %w", err)
 	}
 
 	m := &Module{
-		runtime:    r,
-		wasmEngine: engine,
-		wasmLinker: linker,
-		wasmStore:  store,
-		wasmModule: module,
-		name:       name,
-		wasmCode:   wasmCode,
-		entrypoint: entrypoint,
+		runtime:     r,
+		wasmEngine:  r.engine,
+		wasmLinker:  linker,
+		wasmStore:   store,
+		wasmModule:  module,
+		name:        name,
+		hash:        hash,
+		binaryType:  binaryType,
+		wasmCode:    wasmCode,
+		entrypoint:  entrypoint,
+		minLogLevel: request.GetMinLogLevel(),
+	}
+	if r.fuelMetering {
+		if err := m.setFuel(unmeteredFuel); err != nil {
+			return nil, fmt.Errorf("setting initial fuel: %w", err)
+		}
 	}
+
 	if err := m.newImports(); err != nil {
 		return nil, fmt.Errorf("instantiating imports: %w", err)
 	}
+	if binaryType == BinaryTypeWASIV1 {
+		if err := m.registerWASIImports(linker); err != nil {
+			return nil, fmt.Errorf("instantiating wasi imports: %w", err)
+		}
+	}
 	for namespace, imports := range r.extensions {
 		for importName, f := range imports {
 			f := m.newExtensionFunction(ctx, request, namespace, importName, f)
@@ -62,24 +106,42 @@ func (r *Runtime) NewModule(ctx context.Context, request *pbsubstreams.Request,
 	if err != nil {
 		return nil, fmt.Errorf("creating new instance: %w", err)
 	}
-	memory := instance.GetExport(m.wasmStore, "memory").Memory()
+	memoryExport := instance.GetExport(m.wasmStore, "memory")
+	if memoryExport == nil || memoryExport.Memory() == nil {
+		return nil, fmt.Errorf("module %q does not export its memory", name)
+	}
 
-	alloc := instance.GetExport(m.wasmStore, "alloc").Func()
-	dealloc := instance.GetExport(m.wasmStore, "dealloc").Func()
-	if alloc == nil || dealloc == nil {
-		panic("missing malloc or free")
+	// `alloc` and `dealloc` are optional for WASI modules, the host only needs them to hand values back
+	alloc := exportedFunc(instance, m.wasmStore, "alloc")
+	dealloc := exportedFunc(instance, m.wasmStore, "dealloc")
+	if binaryType == BinaryTypeRustV1 && (alloc == nil || dealloc == nil) {
+		return nil, fmt.Errorf("module %q does not export %q and %q", name, "alloc", "dealloc")
 	}
 
-	heap := NewHeap(memory, alloc, dealloc, m.wasmStore)
+	heap := NewHeap(memoryExport.Memory(), alloc, dealloc, m.wasmStore)
 	m.Heap = heap
 	m.wasmInstance = instance
+
+	if binaryType == BinaryTypeWASIV1 {
+		if err := m.initializeWASI(); err != nil {
+			return nil, fmt.Errorf("initializing module %q: %w", name, err)
+		}
+	}
 	return m, nil
 }
 
+func exportedFunc(instance *wasmtime.Instance, store *wasmtime.Store, name string) *wasmtime.Func {
+	export := instance.GetExport(store, name)
+	if export == nil {
+		return nil
+	}
+	return export.Func()
+}
+
 func (m *Module) NewInstance(clock *pbsubstreams.Clock, arguments []Argument) (*Instance, error) {
-	entrypoint := m.wasmInstance.GetExport(m.wasmStore, m.entrypoint).Func()
+	entrypoint := exportedFunc(m.wasmInstance, m.wasmStore, m.entrypoint)
 	if entrypoint == nil {
-		return nil, fmt.Errorf("failed to get exported function %q", entrypoint)
+		return nil, fmt.Errorf("failed to get exported function %q", m.entrypoint)
 	}
 
 	m.CurrentInstance = &Instance{
@@ -97,9 +159,17 @@ func (m *Module) NewInstance(clock *pbsubstreams.Clock, arguments []Argument) (*
 			m.CurrentInstance.valueType = v.ValueType
 		case *StoreReaderInput:
 			m.CurrentInstance.inputStores = append(m.CurrentInstance.inputStores, v.Store)
+			if m.binaryType == BinaryTypeWASIV1 {
+				continue
+			}
 			args = append(args, int32(len(m.CurrentInstance.inputStores)-1))
 		case ValueArgument:
 			cnt := v.Value()
+			if m.binaryType == BinaryTypeWASIV1 {
+				// pulled by the module through `env.input_len` and `env.input_read`
+				m.CurrentInstance.inputs = append(m.CurrentInstance.inputs, cnt)
+				continue
+			}
 			ptr, err := m.Heap.Write(cnt, input.Name())
 			if err != nil {
 				return nil, fmt.Errorf("writing %s to heap: %w", input.Name(), err)
@@ -120,8 +190,19 @@ func (m *Module) newExtensionFunction(ctx context.Context, request *pbsubstreams
 		heap := m.Heap
 
 		data := heap.ReadBytes(ptr, length)
+		clock := m.CurrentInstance.clock
+		cache := m.runtime.extensionCache
+
+		if cache != nil {
+			if out, found := cache.get(ctx, namespace, name, clock.Id, data); found {
+				if err := m.CurrentInstance.WriteOutputToHeap(outputPtr, out, name); err != nil {
+					panic(fmt.Errorf("write output to heap %w", err))
+				}
+				return
+			}
+		}
 
-		out, err := f(ctx, request, m.CurrentInstance.clock, data)
+		out, err := f(ctx, request, clock, data)
 		if err != nil {
 			panic(fmt.Errorf(`running wasm extension "%s::%s": %w`, namespace, name, err))
 		}
@@ -132,6 +213,10 @@ func (m *Module) newExtensionFunction(ctx context.Context, request *pbsubstreams
 			panic(fmt.Errorf("running wasm extension has been stop upstream in the call stack: %w", ctx.Err()))
 		}
 
+		if cache != nil {
+			cache.put(ctx, namespace, name, clock.Id, data, out)
+		}
+
 		err = m.CurrentInstance.WriteOutputToHeap(outputPtr, out, name)
 		if err != nil {
 			panic(fmt.Errorf("write output to heap %w", err))
@@ -160,7 +245,7 @@ func (m *Module) newImports() error {
 				filename = m.Heap.ReadString(filenamePtr, filenameLength)
 			}
 
-			m.CurrentInstance.panicError = &PanicError{message, filename, int(lineNumber), int(columnNumber)}
+			m.CurrentInstance.panicError = &PanicError{message: message, filename: filename, lineNumber: int(lineNumber), columnNumber: int(columnNumber)}
 		},
 	); err != nil {
 		return fmt.Errorf("registering panic import: %w", err)
@@ -192,21 +277,40 @@ func (m *Module) registerLoggerImports(linker *wasmtime.Linker) error {
 			}
 
 			message := m.Heap.ReadString(ptr, length)
-			if tracer.Enabled() {
-				zlog.Debug(message, zap.String("module_name", m.CurrentInstance.Module.name), zap.String("wasm_file", m.CurrentInstance.Module.name))
+			m.CurrentInstance.appendLog(pbsubstreams.LogLevel_LOG_LEVEL_INFO, message, nil)
+			return
+		},
+	); err != nil {
+		return fmt.Errorf("registering println import: %w", err)
+	}
+
+	if err := linker.FuncWrap("logger", "log",
+		func(level int32, messagePtr, messageLength int32, fieldsPtr, fieldsLength int32) *wasmtime.Trap {
+			logLevel := pbsubstreams.LogLevel(level)
+			if _, found := pbsubstreams.LogLevel_name[level]; !found || logLevel == pbsubstreams.LogLevel_LOG_LEVEL_UNSET {
+				return wasmtime.NewTrap(fmt.Sprintf("invalid log level %d", level))
+			}
+			if m.CurrentInstance.ReachedLogsMaxByteCount() || !m.CurrentInstance.logLevelEnabled(logLevel) {
+				return nil
 			}
 
-			// len(<string>) in Go count number of bytes and not characters, so we are good here
-			m.CurrentInstance.LogsByteCount += uint64(len(message))
-			if !m.CurrentInstance.ReachedLogsMaxByteCount() {
-				m.CurrentInstance.Logs = append(m.CurrentInstance.Logs, message)
-				m.CurrentInstance.PushExecutionStack(fmt.Sprintf("log: %s", message))
+			if messageLength+fieldsLength > maxLogByteCount {
+				return wasmtime.NewTrap(fmt.Sprintf("message to log is too big, max size is %s", humanize.IBytes(uint64(maxLogByteCount))))
 			}
 
-			return
+			fields := &pbsubstreams.LogFields{}
+			if fieldsLength != 0 {
+				if err := proto.Unmarshal(m.Heap.ReadBytes(fieldsPtr, fieldsLength), fields); err != nil {
+					return wasmtime.NewTrap(fmt.Sprintf("decoding log fields: %s", err))
+				}
+			}
+
+			message := m.Heap.ReadString(messagePtr, messageLength)
+			m.CurrentInstance.appendLog(logLevel, message, fields.Fields)
+			return nil
 		},
 	); err != nil {
-		return fmt.Errorf("registering println import: %w", err)
+		return fmt.Errorf("registering log import: %w", err)
 	}
 	return nil
 }
@@ -237,22 +341,31 @@ func (m *Module) registerStateImports(linker *wasmtime.Linker) error {
 	functions["set"] = m.set
 	functions["set_if_not_exists"] = m.setIfNotExists
 	functions["append"] = m.append
+	functions["delete"] = m.delete
 	functions["delete_prefix"] = m.deletePrefix
+	functions["delete_range"] = m.deleteRange
 	functions["add_bigint"] = m.addBigInt
 	functions["add_bigfloat"] = m.addBigFloat
+	functions["add_bigdecimal"] = m.addBigDecimal
 	functions["add_int64"] = m.addInt64
 	functions["add_float64"] = m.addFloat64
 	functions["set_min_int64"] = m.setMinInt64
 	functions["set_min_bigint"] = m.setMinBigint
 	functions["set_min_float64"] = m.setMinfloat64
 	functions["set_min_bigfloat"] = m.setMinBigfloat
+	functions["set_min_bigdecimal"] = m.setMinBigDecimal
 	functions["set_max_int64"] = m.setMaxInt64
 	functions["set_max_bigint"] = m.setMaxBigint
 	functions["set_max_float64"] = m.setMaxFloat64
 	functions["set_max_bigfloat"] = m.setMaxBigfloat
+	functions["set_max_bigdecimal"] = m.setMaxBigDecimal
 	functions["get_at"] = m.getAt
 	functions["get_first"] = m.getFirst
 	functions["get_last"] = m.getLast
+	functions["has_at"] = m.hasAt
+	functions["has_first"] = m.hasFirst
+	functions["has_last"] = m.hasLast
+	functions["scan_prefix"] = m.scanPrefix
 
 	for n, f := range functions {
 		if err := linker.FuncWrap("state", n, f); err != nil {
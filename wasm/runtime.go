@@ -1,9 +1,82 @@
 package wasm
 
-import "fmt"
+import (
+	"fmt"
 
+	"github.com/bytecodealliance/wasmtime-go"
+)
+
+// Runtime holds what is shared by all modules of all requests: the registered
+// extensions, the single `wasmtime.Engine` of the process and the cache of
+// compiled modules built on it. Create it once and reuse it across requests.
 type Runtime struct {
 	extensions map[string]map[string]WASMExtension
+
+	engine      *wasmtime.Engine
+	moduleCache *ModuleCache
+
+	compilationCacheDir string
+	moduleCacheSize     int
+	fuelMetering        bool
+	maxMemory           uint64
+	extensionCache      *ExtensionCache
+
+	skipStoreValueValidation bool
+
+	memoryPeaks *memoryPeaks
+}
+
+type RuntimeOption func(r *Runtime)
+
+// WithCompilationCacheDir persists compiled modules in `dir` so they
+// survive process restarts.
+func WithCompilationCacheDir(dir string) RuntimeOption {
+	return func(r *Runtime) {
+		r.compilationCacheDir = dir
+	}
+}
+
+// WithModuleCacheSize bounds the number of compiled modules kept in memory,
+// `DefaultModuleCacheSize` by default.
+func WithModuleCacheSize(size int) RuntimeOption {
+	return func(r *Runtime) {
+		r.moduleCacheSize = size
+	}
+}
+
+// WithFuelMetering enables wasmtime fuel consumption on the engine, required
+// to enforce execution budgets through `Instance.SetFuelLimit`.
+func WithFuelMetering() RuntimeOption {
+	return func(r *Runtime) {
+		r.fuelMetering = true
+	}
+}
+
+// WithMaxMemory bounds the linear memory of each module to `bytes`, rounded
+// down to a whole number of 64 KiB pages. Growing past it traps the module.
+func WithMaxMemory(bytes uint64) RuntimeOption {
+	return func(r *Runtime) {
+		r.maxMemory = bytes
+	}
+}
+
+// WithExtensionCache memoizes the results of the WASM extensions in `cache`.
+func WithExtensionCache(cache *ExtensionCache) RuntimeOption {
+	return func(r *Runtime) {
+		r.extensionCache = cache
+	}
+}
+
+// WithoutStoreValueValidation lets modules write values malformed for the
+// value type of their store, which are otherwise rejected.
+func WithoutStoreValueValidation() RuntimeOption {
+	return func(r *Runtime) {
+		r.skipStoreValueValidation = true
+	}
+}
+
+func (r *Runtime) maxMemoryPages() uint64 {
+	return r.maxMemory / wasmPageSize
 }
 
 func (r *Runtime) registerWASMExtension(namespace string, importName string, ext WASMExtension) {
@@ -29,8 +102,18 @@ func (r *Runtime) registerWASMExtension(namespace string, importName string, ext
 	r.extensions[namespace][importName] = ext
 }
 
-func NewRuntime(extensions []WASMExtensioner) *Runtime {
+func NewRuntime(extensions []WASMExtensioner, opts ...RuntimeOption) *Runtime {
 	r := &Runtime{}
+	for _, opt := range opts {
+		opt(r)
+	}
+	r.memoryPeaks = newMemoryPeaks(r.moduleCacheSize)
+
+	config := wasmtime.NewConfig()
+	config.SetConsumeFuel(r.fuelMetering)
+	r.engine = wasmtime.NewEngineWithConfig(config)
+	r.moduleCache = NewModuleCache(r.engine, r.compilationCacheDir, r.moduleCacheSize)
+
 	for _, ext := range extensions {
 		for ns, exts := range ext.WASMExtensions() {
 			for name, ext := range exts {
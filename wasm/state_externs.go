@@ -3,8 +3,11 @@ package wasm
 import (
 	"fmt"
 	"math/big"
+	"strconv"
 
 	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/streamingfast/substreams/store"
+	"google.golang.org/protobuf/proto"
 )
 
 func returnStateErrorString(cause string) {
@@ -14,12 +17,53 @@ func returnStateError(cause error) {
 	returnError("state", cause)
 }
 
+// validateValue fails the module when `value`, written to `key`, is not a
+// valid `valueType` value, unless the runtime disabled the validation.
+func (m *Module) validateValue(key string, valueType string, value []byte) {
+	if m.runtime.skipStoreValueValidation {
+		return
+	}
+	if err := store.ValidateValue(valueType, value); err != nil {
+		returnStateError(fmt.Errorf("key %q: %w", key, err))
+	}
+}
+
+func parseBigInt(key string, value string) *big.Int {
+	out, ok := new(big.Int).SetString(value, 10)
+	if !ok {
+		returnStateError(fmt.Errorf("key %q: invalid bigint value %q", key, value))
+	}
+	return out
+}
+
+func parseBigDecimal(key string, value string) *big.Rat {
+	out, err := store.ParseBigDecimal(value)
+	if err != nil {
+		returnStateError(fmt.Errorf("key %q: %w", key, err))
+	}
+	return out
+}
+
+func (m *Module) parseBigFloat(key string, value string) *big.Float {
+	m.validateValue(key, store.OutputValueTypeBigFloat, []byte(value))
+	out, _, err := big.ParseFloat(value, 10, 100, big.ToNearestEven) // corresponds to SumBigFloat's read of the kv value
+	if err != nil {
+		returnStateError(fmt.Errorf("key %q: parsing bigfloat: %w", key, err))
+	}
+	return out
+}
+
+func (m *Module) validateFloat64(key string, value float64) {
+	m.validateValue(key, store.OutputValueTypeFloat64, []byte(strconv.FormatFloat(value, 'g', -1, 64)))
+}
+
 func (m *Module) set(ord int64, keyPtr, keyLength, valPtr, valLength int32) {
 	if m.CurrentInstance.outputStore == nil && m.CurrentInstance.updatePolicy != pbsubstreams.Module_KindStore_UPDATE_POLICY_SET {
 		returnStateErrorString("invalid store operation: 'set' only valid for stores with updatePolicy == 'replace'")
 	}
 	key := m.Heap.ReadString(keyPtr, keyLength)
 	value := m.Heap.ReadBytes(valPtr, valLength)
+	m.validateValue(key, m.CurrentInstance.valueType, value)
 
 	m.CurrentInstance.outputStore.SetBytes(uint64(ord), key, value)
 	m.CurrentInstance.PushExecutionStack(fmt.Sprintf("%s.set  %q", m.name, key))
@@ -31,6 +75,7 @@ func (m *Module) setIfNotExists(ord int64, keyPtr, keyLength, valPtr, valLength
 	}
 	key := m.Heap.ReadString(keyPtr, keyLength)
 	value := m.Heap.ReadBytes(valPtr, valLength)
+	m.validateValue(key, m.CurrentInstance.valueType, value)
 
 	m.CurrentInstance.outputStore.SetBytesIfNotExists(uint64(ord), key, value)
 	m.CurrentInstance.PushExecutionStack(fmt.Sprintf("%s.setIfNotExists  %q", m.name, key))
@@ -43,6 +88,7 @@ func (m *Module) append(ord int64, keyPtr, keyLength, valPtr, valLength int32) {
 
 	key := m.Heap.ReadString(keyPtr, keyLength)
 	value := m.Heap.ReadBytes(valPtr, valLength)
+	m.validateValue(key, m.CurrentInstance.valueType, value)
 
 	m.CurrentInstance.outputStore.Append(uint64(ord), key, value)
 	m.CurrentInstance.PushExecutionStack(fmt.Sprintf("%s.append  %q", m.name, key))
@@ -54,6 +100,19 @@ func (m *Module) deletePrefix(ord int64, keyPtr, keyLength int32) {
 	m.CurrentInstance.PushExecutionStack(fmt.Sprintf("%s.deletePrefix  %s ", m.name, prefix))
 }
 
+func (m *Module) delete(ord int64, keyPtr, keyLength int32) {
+	key := m.Heap.ReadString(keyPtr, keyLength)
+	m.CurrentInstance.outputStore.Del(uint64(ord), key)
+	m.CurrentInstance.PushExecutionStack(fmt.Sprintf("%s.delete  %q", m.name, key))
+}
+
+func (m *Module) deleteRange(ord int64, lowKeyPtr, lowKeyLength, highKeyPtr, highKeyLength int32) {
+	lowKey := m.Heap.ReadString(lowKeyPtr, lowKeyLength)
+	highKey := m.Heap.ReadString(highKeyPtr, highKeyLength)
+	m.CurrentInstance.outputStore.DeleteRange(uint64(ord), lowKey, highKey)
+	m.CurrentInstance.PushExecutionStack(fmt.Sprintf("%s.deleteRange  [%q, %q)", m.name, lowKey, highKey))
+}
+
 func (m *Module) addBigInt(ord int64, keyPtr, keyLength, valPtr, valLength int32) {
 	if m.CurrentInstance.outputStore == nil && m.CurrentInstance.updatePolicy != pbsubstreams.Module_KindStore_UPDATE_POLICY_ADD && m.CurrentInstance.valueType != "bigint" {
 		returnErrorString("state", "invalid store operation: 'add_bigint' only valid for stores with updatePolicy == 'add' and valueType == 'bigint'")
@@ -61,7 +120,7 @@ func (m *Module) addBigInt(ord int64, keyPtr, keyLength, valPtr, valLength int32
 	key := m.Heap.ReadString(keyPtr, keyLength)
 	value := m.Heap.ReadString(valPtr, valLength)
 
-	toAdd, _ := new(big.Int).SetString(value, 10)
+	toAdd := parseBigInt(key, value)
 	m.CurrentInstance.outputStore.SumBigInt(uint64(ord), key, toAdd)
 	m.CurrentInstance.PushExecutionStack(fmt.Sprintf("%s.addBigInt  %q", m.name, key))
 
@@ -76,15 +135,25 @@ func (m *Module) addBigFloat(ord int64, keyPtr, keyLength, valPtr, valLength int
 	key := m.Heap.ReadString(keyPtr, keyLength)
 	value := m.Heap.ReadString(valPtr, valLength)
 
-	toAdd, _, err := big.ParseFloat(value, 10, 100, big.ToNearestEven) // corresponds to SumBigFloat's read of the kv value
-	if err != nil {
-		returnStateError(fmt.Errorf("parsing bigfloat: %w", err))
-	}
+	toAdd := m.parseBigFloat(key, value)
 
 	m.CurrentInstance.outputStore.SumBigFloat(uint64(ord), key, toAdd)
 	m.CurrentInstance.PushExecutionStack(fmt.Sprintf("%s.addBigFloat  %q", m.name, key))
 }
 
+func (m *Module) addBigDecimal(ord int64, keyPtr, keyLength, valPtr, valLength int32) {
+	if m.CurrentInstance.outputStore == nil && m.CurrentInstance.updatePolicy != pbsubstreams.Module_KindStore_UPDATE_POLICY_ADD && m.CurrentInstance.valueType != "bigdecimal" {
+		returnStateErrorString("invalid store operation: 'add_bigdecimal' only valid for stores with updatePolicy == 'add' and valueType == 'bigdecimal'")
+	}
+
+	key := m.Heap.ReadString(keyPtr, keyLength)
+	value := m.Heap.ReadString(valPtr, valLength)
+
+	toAdd := parseBigDecimal(key, value)
+	m.CurrentInstance.outputStore.SumBigDecimal(uint64(ord), key, toAdd)
+	m.CurrentInstance.PushExecutionStack(fmt.Sprintf("%s.addBigDecimal  %q", m.name, key))
+}
+
 func (m *Module) addInt64(ord int64, keyPtr, keyLength int32, value int64) {
 	if m.CurrentInstance.outputStore == nil && m.CurrentInstance.updatePolicy != pbsubstreams.Module_KindStore_UPDATE_POLICY_ADD && m.CurrentInstance.valueType != "int64" {
 		returnStateErrorString("invalid store operation: 'add_int64' only valid for stores with updatePolicy == 'add' and valueType == 'int64'")
@@ -101,6 +170,7 @@ func (m *Module) addFloat64(ord int64, keyPtr, keyLength int32, value float64) {
 		returnStateErrorString("invalid store operation: 'add_float64' only valid for stores with updatePolicy == 'add' and valueType == 'float64'")
 	}
 	key := m.Heap.ReadString(keyPtr, keyLength)
+	m.validateFloat64(key, value)
 
 	m.CurrentInstance.outputStore.SumFloat64(uint64(ord), key, value)
 	m.CurrentInstance.PushExecutionStack(fmt.Sprintf("%s.addFloat64 %q", m.name, key))
@@ -124,7 +194,7 @@ func (m *Module) setMinBigint(ord int64, keyPtr, keyLength, valPtr, valLength in
 	key := m.Heap.ReadString(keyPtr, keyLength)
 	value := m.Heap.ReadString(valPtr, valLength)
 
-	toSet, _ := new(big.Int).SetString(value, 10)
+	toSet := parseBigInt(key, value)
 	m.CurrentInstance.outputStore.SetMinBigInt(uint64(ord), key, toSet)
 	m.CurrentInstance.PushExecutionStack(fmt.Sprintf("%s.setMinBigint %q", m.name, key))
 }
@@ -134,6 +204,7 @@ func (m *Module) setMinfloat64(ord int64, keyPtr, keyLength int32, value float64
 		returnStateErrorString("invalid store operation: 'set_min_float' only valid for stores with updatePolicy == 'min' and valueType == 'float'")
 	}
 	key := m.Heap.ReadString(keyPtr, keyLength)
+	m.validateFloat64(key, value)
 
 	m.CurrentInstance.outputStore.SetMinFloat64(uint64(ord), key, value)
 	m.CurrentInstance.PushExecutionStack(fmt.Sprintf("%s.setMinfloat64 %q", m.name, key))
@@ -147,14 +218,24 @@ func (m *Module) setMinBigfloat(ord int64, keyPtr, keyLength, valPtr, valLength
 	key := m.Heap.ReadString(keyPtr, keyLength)
 	value := m.Heap.ReadString(valPtr, valLength)
 
-	toSet, _, err := big.ParseFloat(value, 10, 100, big.ToNearestEven)
-	if err != nil {
-		returnStateError(fmt.Errorf("parsing bigfloat: %w", err))
-	}
+	toSet := m.parseBigFloat(key, value)
 	m.CurrentInstance.outputStore.SetMinBigFloat(uint64(ord), key, toSet)
 	m.CurrentInstance.PushExecutionStack(fmt.Sprintf("%s.setMinBigfloat %q", m.name, key))
 }
 
+func (m *Module) setMinBigDecimal(ord int64, keyPtr, keyLength, valPtr, valLength int32) {
+	if m.CurrentInstance.outputStore == nil && m.CurrentInstance.updatePolicy != pbsubstreams.Module_KindStore_UPDATE_POLICY_MIN && m.CurrentInstance.valueType != "bigdecimal" {
+		returnStateErrorString("invalid store operation: 'set_min_bigdecimal' only valid for stores with updatePolicy == 'min' and valueType == 'bigdecimal'")
+	}
+
+	key := m.Heap.ReadString(keyPtr, keyLength)
+	value := m.Heap.ReadString(valPtr, valLength)
+
+	toSet := parseBigDecimal(key, value)
+	m.CurrentInstance.outputStore.SetMinBigDecimal(uint64(ord), key, toSet)
+	m.CurrentInstance.PushExecutionStack(fmt.Sprintf("%s.setMinBigDecimal %q", m.name, key))
+}
+
 func (m *Module) setMaxInt64(ord int64, keyPtr, keyLength int32, value int64) {
 	if m.CurrentInstance.outputStore == nil && m.CurrentInstance.updatePolicy != pbsubstreams.Module_KindStore_UPDATE_POLICY_MAX && m.CurrentInstance.valueType != "int64" {
 		returnStateErrorString("invalid store operation: 'set_max_int64' only valid for stores with updatePolicy == 'max' and valueType == 'int64'")
@@ -172,7 +253,7 @@ func (m *Module) setMaxBigint(ord int64, keyPtr, keyLength, valPtr, valLength in
 	key := m.Heap.ReadString(keyPtr, keyLength)
 	value := m.Heap.ReadString(valPtr, valLength)
 
-	toSet, _ := new(big.Int).SetString(value, 10)
+	toSet := parseBigInt(key, value)
 	m.CurrentInstance.outputStore.SetMaxBigInt(uint64(ord), key, toSet)
 	m.CurrentInstance.PushExecutionStack(fmt.Sprintf("%s.setMaxBigInt %q", m.name, key))
 }
@@ -182,6 +263,7 @@ func (m *Module) setMaxFloat64(ord int64, keyPtr, keyLength int32, value float64
 		returnStateErrorString("invalid store operation: 'set_max_float' only valid for stores with updatePolicy == 'max' and valueType == 'float'")
 	}
 	key := m.Heap.ReadString(keyPtr, keyLength)
+	m.validateFloat64(key, value)
 
 	m.CurrentInstance.outputStore.SetMaxFloat64(uint64(ord), key, value)
 	m.CurrentInstance.PushExecutionStack(fmt.Sprintf("%s.setMaxFloat64 %q", m.name, key))
@@ -194,14 +276,24 @@ func (m *Module) setMaxBigfloat(ord int64, keyPtr, keyLength, valPtr, valLength
 	key := m.Heap.ReadString(keyPtr, keyLength)
 	value := m.Heap.ReadString(valPtr, valLength)
 
-	toSet, _, err := big.ParseFloat(value, 10, 100, big.ToNearestEven)
-	if err != nil {
-		returnStateError(fmt.Errorf("parsing bigfloat: %w", err))
-	}
+	toSet := m.parseBigFloat(key, value)
 	m.CurrentInstance.outputStore.SetMaxBigFloat(uint64(ord), key, toSet)
 	m.CurrentInstance.PushExecutionStack(fmt.Sprintf("%s.setMaxBigfloat %q", m.name, key))
 }
 
+func (m *Module) setMaxBigDecimal(ord int64, keyPtr, keyLength, valPtr, valLength int32) {
+	if m.CurrentInstance.outputStore == nil && m.CurrentInstance.updatePolicy != pbsubstreams.Module_KindStore_UPDATE_POLICY_MAX && m.CurrentInstance.valueType != "bigdecimal" {
+		returnStateErrorString("invalid store operation: 'set_max_bigdecimal' only valid for stores with updatePolicy == 'max' and valueType == 'bigdecimal'")
+	}
+
+	key := m.Heap.ReadString(keyPtr, keyLength)
+	value := m.Heap.ReadString(valPtr, valLength)
+
+	toSet := parseBigDecimal(key, value)
+	m.CurrentInstance.outputStore.SetMaxBigDecimal(uint64(ord), key, toSet)
+	m.CurrentInstance.PushExecutionStack(fmt.Sprintf("%s.setMaxBigDecimal %q", m.name, key))
+}
+
 func (m *Module) getAt(storeIndex int32, ord int64, keyPtr, keyLength, outputPtr int32) int32 {
 	if int(storeIndex+1) > len(m.CurrentInstance.inputStores) {
 		returnStateError(fmt.Errorf("'get_at' failed: invalid store index %d, %d stores declared", storeIndex, len(m.CurrentInstance.inputStores)))
@@ -260,3 +352,80 @@ func (m *Module) getLast(storeIndex int32, keyPtr, keyLength, outputPtr int32) i
 	}
 	return 1
 }
+
+func (m *Module) hasAt(storeIndex int32, ord int64, keyPtr, keyLength int32) int32 {
+	if int(storeIndex)+1 > len(m.CurrentInstance.inputStores) {
+		returnStateError(fmt.Errorf("'has_at' failed: invalid store index %d, %d stores declared", storeIndex, len(m.CurrentInstance.inputStores)))
+	}
+	readStore := m.CurrentInstance.inputStores[storeIndex]
+	key := m.Heap.ReadString(keyPtr, keyLength)
+	found := readStore.HasAt(uint64(ord), key)
+	m.CurrentInstance.PushExecutionStack(fmt.Sprintf("%s.hasAt %q: found:%t", m.name, key, found))
+	if !found {
+		return 0
+	}
+	return 1
+}
+
+func (m *Module) hasFirst(storeIndex int32, keyPtr, keyLength int32) int32 {
+	if int(storeIndex)+1 > len(m.CurrentInstance.inputStores) {
+		returnStateError(fmt.Errorf("'has_first' failed: invalid store index %d, %d stores declared", storeIndex, len(m.CurrentInstance.inputStores)))
+	}
+	readStore := m.CurrentInstance.inputStores[storeIndex]
+	key := m.Heap.ReadString(keyPtr, keyLength)
+	found := readStore.HasFirst(key)
+	m.CurrentInstance.PushExecutionStack(fmt.Sprintf("%s.hasFirst %q: found:%t", m.name, key, found))
+	if !found {
+		return 0
+	}
+	return 1
+}
+
+func (m *Module) hasLast(storeIndex int32, keyPtr, keyLength int32) int32 {
+	if int(storeIndex)+1 > len(m.CurrentInstance.inputStores) {
+		returnStateError(fmt.Errorf("'has_last' failed: invalid store index %d, %d stores declared", storeIndex, len(m.CurrentInstance.inputStores)))
+	}
+	readStore := m.CurrentInstance.inputStores[storeIndex]
+	key := m.Heap.ReadString(keyPtr, keyLength)
+	found := readStore.HasLast(key)
+	m.CurrentInstance.PushExecutionStack(fmt.Sprintf("%s.hasLast %q: found:%t", m.name, key, found))
+	if !found {
+		return 0
+	}
+	return 1
+}
+
+// scanPrefix writes a `pbsubstreams.StoreKeyValues` holding the keys starting with the prefix, in lexical
+// order, and returns how many were found. Nothing is written when none are. A `limit` of 0 means no limit.
+func (m *Module) scanPrefix(storeIndex int32, prefixPtr, prefixLength int32, limit int32, outputPtr int32) int32 {
+	if int(storeIndex)+1 > len(m.CurrentInstance.inputStores) {
+		returnStateError(fmt.Errorf("'scan_prefix' failed: invalid store index %d, %d stores declared", storeIndex, len(m.CurrentInstance.inputStores)))
+	}
+	if limit < 0 {
+		returnStateError(fmt.Errorf("'scan_prefix' failed: invalid negative limit %d", limit))
+	}
+	readStore := m.CurrentInstance.inputStores[storeIndex]
+	prefix := m.Heap.ReadString(prefixPtr, prefixLength)
+
+	result := &pbsubstreams.StoreKeyValues{}
+	err := readStore.ScanPrefix(prefix, uint64(limit), func(key string, value []byte) error {
+		result.KeyValues = append(result.KeyValues, &pbsubstreams.StoreKeyValue{Key: key, Value: value})
+		return nil
+	})
+	if err != nil {
+		returnStateError(fmt.Errorf("'scan_prefix' failed: %w", err))
+	}
+	m.CurrentInstance.PushExecutionStack(fmt.Sprintf("%s.scanPrefix %q: found:%d", m.name, prefix, len(result.KeyValues)))
+	if len(result.KeyValues) == 0 {
+		return 0
+	}
+
+	out, err := proto.Marshal(result)
+	if err != nil {
+		returnStateError(fmt.Errorf("marshalling scanned keys: %w", err))
+	}
+	if err := m.CurrentInstance.WriteOutputToHeap(outputPtr, out, prefix); err != nil {
+		returnStateError(fmt.Errorf("writing value to output ptr %d: %w", outputPtr, err))
+	}
+	return int32(len(result.KeyValues))
+}
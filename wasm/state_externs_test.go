@@ -0,0 +1,104 @@
+package wasm
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/bytecodealliance/wasmtime-go"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/streamingfast/substreams/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// calls `state::<import>` with key "key" and the value found at offset 16
+const storeWriteModuleText = `
+(module
+  (import "state" "%s" (func $write (param i64 i32 i32 i32 i32)))
+  (memory (export "memory") 1)
+  (data (i32.const 0) "key")
+  (data (i32.const 16) "%s")
+  (func (export "map_test")
+    (call $write (i64.const 1) (i32.const 0) (i32.const 3) (i32.const 16) (i32.const %d)))
+)`
+
+func TestModule_storeValueValidation(t *testing.T) {
+	tests := []struct {
+		name         string
+		importName   string
+		updatePolicy pbsubstreams.Module_KindStore_UpdatePolicy
+		valueType    string
+		value        string
+		expectError  string
+		expectValue  string
+	}{
+		{"set int64", "set", pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "int64", "-42", "", "-42"},
+		{"set malformed int64", "set", pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "int64", "42a", `key "key": invalid int64 value "42a"`, ""},
+		{"set malformed proto", "set", pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "proto:my.Type", "\\0a\\05ab", `key "key": invalid proto:my.Type value: field 1: unexpected EOF`, ""},
+		{"set string", "set", pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "string", "42a", "", "42a"},
+		{"add malformed bigint", "add_bigint", pbsubstreams.Module_KindStore_UPDATE_POLICY_ADD, "bigint", "12.5", `key "key": invalid bigint value "12.5"`, ""},
+		{"add infinite bigfloat", "add_bigfloat", pbsubstreams.Module_KindStore_UPDATE_POLICY_ADD, "bigfloat", "+Inf", `key "key": invalid bigfloat value "+Inf"`, ""},
+		{"add bigdecimal", "add_bigdecimal", pbsubstreams.Module_KindStore_UPDATE_POLICY_ADD, "bigdecimal", "0.10", "", "0.1"},
+		{"min malformed bigdecimal", "set_min_bigdecimal", pbsubstreams.Module_KindStore_UPDATE_POLICY_MIN, "bigdecimal", "1/3", `key "key": invalid bigdecimal value "1/3"`, ""},
+		{"max bigint", "set_max_bigint", pbsubstreams.Module_KindStore_UPDATE_POLICY_MAX, "bigint", "12", "", "12"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			code, err := wasmtime.Wat2Wasm(fmt.Sprintf(storeWriteModuleText, test.importName, test.value, len(unescapeWat(test.value))))
+			require.NoError(t, err)
+
+			module, err := NewRuntime(nil).NewModule(context.Background(), nil, BinaryTypeWASIV1, code, "map_test", "", "map_test")
+			require.NoError(t, err)
+
+			outputStore := store.NewTestKVStore(t, test.updatePolicy, test.valueType, nil)
+			instance, err := module.NewInstance(&pbsubstreams.Clock{Number: 10}, []Argument{NewStoreWriterOutput("map_test", outputStore, test.updatePolicy, test.valueType)})
+			require.NoError(t, err)
+
+			if test.expectError != "" {
+				// state errors panic through the wasm call, up to the pipeline
+				require.PanicsWithError(t, test.expectError, func() { instance.Execute() })
+				assert.Equal(t, uint64(0), outputStore.Length())
+				return
+			}
+			require.NoError(t, instance.Execute())
+			value, found := outputStore.GetLast("key")
+			require.True(t, found)
+			assert.Equal(t, test.expectValue, string(value))
+		})
+	}
+}
+
+func TestModule_storeValueValidationDisabled(t *testing.T) {
+	code, err := wasmtime.Wat2Wasm(fmt.Sprintf(storeWriteModuleText, "set", "42a", 3))
+	require.NoError(t, err)
+
+	module, err := NewRuntime(nil, WithoutStoreValueValidation()).NewModule(context.Background(), nil, BinaryTypeWASIV1, code, "map_test", "", "map_test")
+	require.NoError(t, err)
+
+	outputStore := store.NewTestKVStore(t, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "int64", nil)
+	instance, err := module.NewInstance(&pbsubstreams.Clock{Number: 10}, []Argument{NewStoreWriterOutput("map_test", outputStore, pbsubstreams.Module_KindStore_UPDATE_POLICY_SET, "int64")})
+	require.NoError(t, err)
+	require.NoError(t, instance.Execute())
+
+	value, found := outputStore.GetLast("key")
+	require.True(t, found)
+	assert.Equal(t, "42a", string(value))
+}
+
+// unescapeWat returns the bytes of a WAT string holding `\hh` escapes
+func unescapeWat(in string) []byte {
+	var out []byte
+	for i := 0; i < len(in); i++ {
+		if in[i] == '\\' {
+			var b byte
+			fmt.Sscanf(in[i+1:i+3], "%02x", &b)
+			out = append(out, b)
+			i += 2
+			continue
+		}
+		out = append(out, in[i])
+	}
+	return out
+}
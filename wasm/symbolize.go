@@ -0,0 +1,219 @@
+package wasm
+
+import (
+	"bytes"
+	"debug/dwarf"
+	"encoding/binary"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/bytecodealliance/wasmtime-go"
+	"go.uber.org/zap"
+)
+
+const (
+	customSectionID = 0
+	codeSectionID   = 10
+)
+
+// symbols resolves the frames of a trap to function names, from the name
+// section, and to source locations when the binary embeds DWARF info.
+type symbols struct {
+	// codeStart is the offset of the code section's content, DWARF addresses
+	// are relative to it.
+	codeStart uint
+	dwarf     *dwarf.Data
+}
+
+type frameSymbol struct {
+	function string
+	file     string
+	line     int
+	column   int
+}
+
+func (f frameSymbol) String() string {
+	if f.file == "" {
+		return f.function
+	}
+	return fmt.Sprintf("%s (%s:%d:%d)", f.function, f.file, f.line, f.column)
+}
+
+func (m *Module) symbolTable() *symbols {
+	if m.symbols == nil {
+		m.symbols = parseSymbols(m.wasmCode)
+	}
+	return m.symbols
+}
+
+// parseSymbols never fails, a binary without (or with unreadable) debug info
+// yields only function names.
+func parseSymbols(code []byte) *symbols {
+	s := &symbols{}
+	if len(code) < 8 || !bytes.Equal(code[0:4], []byte("\x00asm")) {
+		return s
+	}
+
+	debugSections := map[string][]byte{}
+	pos := 8
+	for pos < len(code) {
+		sectionID := code[pos]
+		size, n := binary.Uvarint(code[pos+1:])
+		if n <= 0 {
+			break
+		}
+		start := pos + 1 + n
+		end := start + int(size)
+		if end > len(code) {
+			break
+		}
+
+		switch sectionID {
+		case codeSectionID:
+			s.codeStart = uint(start)
+		case customSectionID:
+			nameLen, n := binary.Uvarint(code[start:end])
+			if n > 0 && start+n+int(nameLen) <= end {
+				name := string(code[start+n : start+n+int(nameLen)])
+				if strings.HasPrefix(name, ".debug_") {
+					debugSections[name] = code[start+n+int(nameLen) : end]
+				}
+			}
+		}
+		pos = end
+	}
+
+	if debugSections[".debug_info"] == nil || debugSections[".debug_line"] == nil {
+		return s
+	}
+	d, err := dwarf.New(
+		debugSections[".debug_abbrev"],
+		debugSections[".debug_aranges"],
+		debugSections[".debug_frame"],
+		debugSections[".debug_info"],
+		debugSections[".debug_line"],
+		debugSections[".debug_pubnames"],
+		debugSections[".debug_ranges"],
+		debugSections[".debug_str"],
+	)
+	if err != nil {
+		zlog.Debug("ignoring unreadable dwarf info", zap.Error(err))
+		return s
+	}
+	for _, name := range []string{".debug_addr", ".debug_line_str", ".debug_str_offsets", ".debug_rnglists"} {
+		if content, found := debugSections[name]; found {
+			if err := d.AddSection(name, content); err != nil {
+				zlog.Debug("ignoring unreadable dwarf section", zap.Error(err))
+			}
+		}
+	}
+	s.dwarf = d
+	return s
+}
+
+func (s *symbols) symbolize(frame *wasmtime.Frame) frameSymbol {
+	out := frameSymbol{function: fmt.Sprintf("func[%d]", frame.FuncIndex())}
+	if name := frame.FuncName(); name != nil && *name != "" {
+		out.function = demangle(*name)
+	}
+
+	if s.dwarf != nil && frame.ModuleOffset() >= s.codeStart {
+		out.file, out.line, out.column = s.location(uint64(frame.ModuleOffset() - s.codeStart))
+	}
+	return out
+}
+
+func (s *symbols) location(pc uint64) (file string, line, column int) {
+	reader := s.dwarf.Reader()
+	for {
+		entry, err := reader.Next()
+		if err != nil || entry == nil {
+			return
+		}
+		if entry.Tag != dwarf.TagCompileUnit {
+			reader.SkipChildren()
+			continue
+		}
+		reader.SkipChildren()
+
+		ranges, err := s.dwarf.Ranges(entry)
+		if err != nil || !inRanges(ranges, pc) {
+			continue
+		}
+		lineReader, err := s.dwarf.LineReader(entry)
+		if err != nil || lineReader == nil {
+			continue
+		}
+		var lineEntry dwarf.LineEntry
+		if err := lineReader.SeekPC(pc, &lineEntry); err != nil {
+			continue
+		}
+		if lineEntry.File != nil {
+			file = lineEntry.File.Name
+		}
+		return file, lineEntry.Line, lineEntry.Column
+	}
+}
+
+func inRanges(ranges [][2]uint64, pc uint64) bool {
+	for _, r := range ranges {
+		if pc >= r[0] && pc < r[1] {
+			return true
+		}
+	}
+	return false
+}
+
+var rustHashSuffix = regexp.MustCompile(`::h[0-9a-f]{16}$`)
+
+var rustLegacyEscapes = strings.NewReplacer(
+	"$SP$", "@", "$BP$", "*", "$RF$", "&", "$LT$", "<", "$GT$", ">",
+	"$LP$", "(", "$RP$", ")", "$C$", ",", "$u20$", " ", "$u27$", "'",
+	"$u5b$", "[", "$u5d$", "]", "$u7b$", "{", "$u7d$", "}", "$u7e$", "~",
+	"..", "::",
+)
+
+// demangle turns legacy Rust symbols (`_ZN4core9panicking5panic17h0123456789abcdefE`)
+// into their path (`core::panicking::panic`), other names are only stripped
+// of their trailing hash.
+func demangle(name string) string {
+	if strings.HasPrefix(name, "_ZN") && strings.HasSuffix(name, "E") {
+		if path, ok := demangleRustLegacy(name[3 : len(name)-1]); ok {
+			name = path
+		}
+	}
+	return rustHashSuffix.ReplaceAllString(name, "")
+}
+
+func demangleRustLegacy(mangled string) (string, bool) {
+	var parts []string
+	for len(mangled) > 0 {
+		digits := 0
+		for digits < len(mangled) && mangled[digits] >= '0' && mangled[digits] <= '9' {
+			digits++
+		}
+		length, err := strconv.Atoi(mangled[:digits])
+		if digits == 0 || err != nil || digits+length > len(mangled) {
+			return "", false
+		}
+		part := mangled[digits : digits+length]
+		if strings.HasPrefix(part, "_$") {
+			part = part[1:]
+		}
+		parts = append(parts, rustLegacyEscapes.Replace(part))
+		mangled = mangled[digits+length:]
+	}
+	return strings.Join(parts, "::"), len(parts) > 0
+}
+
+// trapMessage strips the backtrace wasmtime appends to the message, frames
+// are reported symbolized instead.
+func trapMessage(trap *wasmtime.Trap) string {
+	message := trap.Message()
+	if idx := strings.Index(message, "\nwasm backtrace:"); idx != -1 {
+		message = message[:idx]
+	}
+	return message
+}
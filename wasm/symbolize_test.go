@@ -0,0 +1,153 @@
+package wasm
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/bytecodealliance/wasmtime-go"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const trappingModuleText = `
+(module
+  (memory (export "memory") 1)
+  (func $_ZN8my_crate5inner17h0123456789abcdefE unreachable)
+  (func $map_test (export "map_test") call $_ZN8my_crate5inner17h0123456789abcdefE)
+)`
+
+func TestInstance_trapFrames(t *testing.T) {
+	code, err := wasmtime.Wat2Wasm(trappingModuleText)
+	require.NoError(t, err)
+
+	module, err := NewRuntime(nil).NewModule(context.Background(), nil, BinaryTypeWASIV1, code, "map_test", "", "map_test")
+	require.NoError(t, err)
+
+	instance, err := module.NewInstance(&pbsubstreams.Clock{Number: 1}, nil)
+	require.NoError(t, err)
+
+	err = instance.Execute()
+	require.Error(t, err)
+
+	var panicErr *PanicError
+	require.True(t, errors.As(err, &panicErr))
+	assert.Equal(t, "panic in the wasm: \"wasm trap: wasm `unreachable` instruction executed\"", panicErr.Error())
+	assert.Equal(t, []string{"my_crate::inner", "map_test"}, panicErr.Frames())
+	assert.Equal(t, []string{"backtrace: #0 my_crate::inner", "backtrace: #1 map_test"}, instance.ExecutionStack)
+}
+
+func TestDemangle(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"map_test", "map_test"},
+		{"_ZN4core9panicking5panic17h0123456789abcdefE", "core::panicking::panic"},
+		{"_ZN51_$LT$my_crate..Pool$u20$as$u20$core..fmt..Debug$GT$3fmt17h0123456789abcdefE", "<my_crate::Pool as core::fmt::Debug>::fmt"},
+		{"my_crate::handler::h0123456789abcdef", "my_crate::handler"},
+		{"_ZN4core5panic", "_ZN4core5panic"},
+		{"_ZN99tooshortE", "_ZN99tooshortE"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, demangle(test.name))
+		})
+	}
+}
+
+func appendUint32(buf []byte, value uint32) []byte {
+	var tmp [4]byte
+	binary.LittleEndian.PutUint32(tmp[:], value)
+	return append(buf, tmp[:]...)
+}
+
+// withDWARF appends to `code` the DWARF sections of a compile unit
+// `src/lib.rs` covering the addresses up to `codeSize`, mapping the
+// instruction at `pc` to line 42, column 7, the ones before it to line 1 and
+// the ones after it to line 50, column 3.
+func withDWARF(code []byte, codeSize uint32, pc uint32) []byte {
+	abbrev := []byte{
+		1, 0x11, 0, // compile unit, no children
+		0x03, 0x08, // name, string
+		0x10, 0x17, // stmt_list, sec_offset
+		0x11, 0x01, // low_pc, addr
+		0x12, 0x06, // high_pc, data4
+		0, 0, 0,
+	}
+
+	unit := []byte{4, 0, 0, 0, 0, 0, 4} // version 4, abbrev offset 0, address size 4
+	unit = append(unit, 1)
+	unit = append(unit, "src/lib.rs\x00"...)
+	unit = appendUint32(unit, 0)
+	unit = appendUint32(unit, 0)
+	unit = appendUint32(unit, codeSize)
+	info := appendUint32(nil, uint32(len(unit)))
+	info = append(info, unit...)
+
+	// instruction length 1, 1 op per instruction, is_stmt, line base -5,
+	// line range 14, opcode base 13, then the standard opcode lengths
+	header := []byte{1, 1, 1, 0xfb, 14, 13, 0, 1, 1, 1, 1, 0, 0, 0, 1, 0, 0, 1}
+	header = append(header, 0)                         // no include directories
+	header = append(header, "src/lib.rs\x00"...)       // file 1
+	header = append(header, 0, 0, 0, 0)                // directory, mtime, length, end of files
+	program := []byte{0x00, 5, 0x02, 0, 0, 0, 0, 0x01} // set_address 0, copy
+	program = append(program, 0x00, 5, 0x02)
+	program = appendUint32(program, pc)
+	program = append(program, 0x03, 41, 0x05, 7, 0x01) // advance_line 41, set_column 7, copy
+	program = append(program, 0x00, 5, 0x02)
+	program = appendUint32(program, pc+1)
+	program = append(program, 0x03, 8, 0x05, 3, 0x01) // advance_line 8, set_column 3, copy
+	program = append(program, 0x00, 5, 0x02)
+	program = appendUint32(program, codeSize)
+	program = append(program, 0x00, 1, 0x01) // end_sequence
+
+	lineUnit := []byte{4, 0}
+	lineUnit = appendUint32(lineUnit, uint32(len(header)))
+	lineUnit = append(lineUnit, header...)
+	lineUnit = append(lineUnit, program...)
+	line := appendUint32(nil, uint32(len(lineUnit)))
+	line = append(line, lineUnit...)
+
+	out := append([]byte{}, code...)
+	for _, section := range []struct {
+		name    string
+		content []byte
+	}{{".debug_abbrev", abbrev}, {".debug_info", info}, {".debug_line", line}} {
+		content := appendUvarint(nil, uint64(len(section.name)))
+		content = append(content, section.name...)
+		content = append(content, section.content...)
+		out = append(out, customSectionID)
+		out = appendUvarint(out, uint64(len(content)))
+		out = append(out, content...)
+	}
+	return out
+}
+
+func TestInstance_trapFramesDWARF(t *testing.T) {
+	code, err := wasmtime.Wat2Wasm(trappingModuleText)
+	require.NoError(t, err)
+
+	execute := func(code []byte) *Instance {
+		module, err := NewRuntime(nil).NewModule(context.Background(), nil, BinaryTypeWASIV1, code, "map_test", "", "map_test")
+		require.NoError(t, err)
+		instance, err := module.NewInstance(&pbsubstreams.Clock{Number: 1}, nil)
+		require.NoError(t, err)
+		return instance
+	}
+
+	// locate the trapping `unreachable`, DWARF addresses are relative to the code section
+	var trap *wasmtime.Trap
+	require.True(t, errors.As(execute(code).call(), &trap))
+	pc := trap.Frames()[0].ModuleOffset() - parseSymbols(code).codeStart
+
+	var panicErr *PanicError
+	require.True(t, errors.As(execute(withDWARF(code, uint32(len(code)), uint32(pc))).Execute(), &panicErr))
+	assert.Equal(t, "src/lib.rs", panicErr.filename)
+	assert.Equal(t, 42, panicErr.lineNumber)
+	assert.Equal(t, 7, panicErr.columnNumber)
+	assert.Equal(t, []string{"my_crate::inner (src/lib.rs:42:7)", "map_test (src/lib.rs:50:3)"}, panicErr.Frames())
+}
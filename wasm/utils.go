@@ -9,8 +9,21 @@ type PanicError struct {
 	filename     string
 	lineNumber   int
 	columnNumber int
+
+	// frames is the symbolized backtrace of the trap, innermost first
+	frames []string
 }
 
 func (e *PanicError) Error() string {
+	if e.filename == "" {
+		return fmt.Sprintf("panic in the wasm: %q", e.message)
+	}
 	return fmt.Sprintf("panic in the wasm: %q at %s:%d:%d", e.message, e.filename, e.lineNumber, e.columnNumber)
 }
+
+// Frames returns the wasm backtrace of the panic, innermost first, as
+// `function (file:line:column)` when the binary embeds debug info, or as
+// `function` otherwise.
+func (e *PanicError) Frames() []string {
+	return e.frames
+}
@@ -0,0 +1,172 @@
+package wasm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/bytecodealliance/wasmtime-go"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"go.uber.org/zap"
+)
+
+// WASI errno values returned by the subset of `wasi_snapshot_preview1`
+// implemented by the runtime.
+const (
+	wasiErrnoSuccess int32 = 0
+	wasiErrnoBadf    int32 = 8
+	wasiErrnoNosys   int32 = 52
+)
+
+type ProcExitError struct {
+	Code int32
+}
+
+func (e *ProcExitError) Error() string {
+	return fmt.Sprintf("module exited with code %d", e.Code)
+}
+
+// registerWASIImports provides the `env` functions specific to `wasm/wasi-v1`
+// modules and a deterministic subset of `wasi_snapshot_preview1`: there is no
+// filesystem, no environment, no clock and randomness is all zeroes.
+func (m *Module) registerWASIImports(linker *wasmtime.Linker) error {
+	env := map[string]interface{}{
+		"input_len": func(index int32) (int32, *wasmtime.Trap) {
+			input, trap := m.CurrentInstance.input(index)
+			if trap != nil {
+				return 0, trap
+			}
+			return int32(len(input)), nil
+		},
+		"input_read": func(index, ptr int32) *wasmtime.Trap {
+			input, trap := m.CurrentInstance.input(index)
+			if trap != nil {
+				return trap
+			}
+			if _, err := m.Heap.WriteAtPtr(input, ptr, "input_read"); err != nil {
+				return wasmtime.NewTrap(err.Error())
+			}
+			return nil
+		},
+	}
+	for name, f := range env {
+		if err := linker.FuncWrap("env", name, f); err != nil {
+			return fmt.Errorf("registering %q: %w", name, err)
+		}
+	}
+
+	noArgs := func(countPtr, sizePtr int32) int32 {
+		m.writeUint32(countPtr, 0)
+		m.writeUint32(sizePtr, 0)
+		return wasiErrnoSuccess
+	}
+
+	wasi := map[string]interface{}{
+		"fd_write": func(fd, iovsPtr, iovsLen, nwrittenPtr int32) int32 {
+			if fd != 1 && fd != 2 {
+				return wasiErrnoBadf
+			}
+			written := 0
+			for i := int32(0); i < iovsLen; i++ {
+				iov := m.Heap.ReadBytes(iovsPtr+i*8, 8)
+				ptr := int32(binary.LittleEndian.Uint32(iov[0:4]))
+				length := int32(binary.LittleEndian.Uint32(iov[4:8]))
+				m.CurrentInstance.writeStdio(m.Heap.ReadBytes(ptr, length))
+				written += int(length)
+			}
+			m.writeUint32(nwrittenPtr, uint32(written))
+			return wasiErrnoSuccess
+		},
+		"proc_exit": func(code int32) *wasmtime.Trap {
+			m.CurrentInstance.exitCode = &code
+			return wasmtime.NewTrap(fmt.Sprintf("proc_exit(%d)", code))
+		},
+		"args_sizes_get":    noArgs,
+		"environ_sizes_get": noArgs,
+		"args_get":          func(argvPtr, bufPtr int32) int32 { return wasiErrnoSuccess },
+		"environ_get":       func(environPtr, bufPtr int32) int32 { return wasiErrnoSuccess },
+		"random_get": func(ptr, length int32) int32 {
+			if _, err := m.Heap.WriteAtPtr(make([]byte, length), ptr, "random_get"); err != nil {
+				return wasiErrnoNosys
+			}
+			return wasiErrnoSuccess
+		},
+		"clock_time_get": func(clockID int32, precision int64, timePtr int32) int32 { return wasiErrnoNosys },
+		"sched_yield":    func() int32 { return wasiErrnoSuccess },
+		"fd_close":       func(fd int32) int32 { return wasiErrnoBadf },
+		"fd_fdstat_get":  func(fd, statPtr int32) int32 { return wasiErrnoBadf },
+		"fd_prestat_get": func(fd, prestatPtr int32) int32 { return wasiErrnoBadf },
+		"fd_seek":        func(fd int32, offset int64, whence, newOffsetPtr int32) int32 { return wasiErrnoBadf },
+		"fd_read":        func(fd, iovsPtr, iovsLen, nreadPtr int32) int32 { return wasiErrnoBadf },
+	}
+	for name, f := range wasi {
+		if err := linker.FuncWrap("wasi_snapshot_preview1", name, f); err != nil {
+			return fmt.Errorf("registering %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// initializeWASI runs the module's `_initialize` reactor export, if any. What
+// it prints goes to the process logs since there is no request to attach it to.
+func (m *Module) initializeWASI() error {
+	initialize := exportedFunc(m.wasmInstance, m.wasmStore, "_initialize")
+	if initialize == nil {
+		return nil
+	}
+
+	m.CurrentInstance = &Instance{Module: m, entrypoint: initialize}
+	defer func() { m.CurrentInstance = nil }()
+
+	err := m.CurrentInstance.call()
+	for _, line := range m.CurrentInstance.Logs {
+		zlog.Info(line, zap.String("module_name", m.name))
+	}
+	return err
+}
+
+func (m *Module) writeUint32(ptr int32, value uint32) {
+	buf := make([]byte, 4)
+	binary.LittleEndian.PutUint32(buf, value)
+	m.Heap.WriteAtPtr(buf, ptr, "wasi")
+}
+
+func (i *Instance) input(index int32) ([]byte, *wasmtime.Trap) {
+	if index < 0 || int(index) >= len(i.inputs) {
+		return nil, wasmtime.NewTrap(fmt.Sprintf("input index %d out of range, module has %d inputs", index, len(i.inputs)))
+	}
+	return i.inputs[index], nil
+}
+
+// writeStdio turns what the module writes to stdout and stderr into log
+// lines, a trailing partial line is kept until the next newline or the end
+// of the execution.
+func (i *Instance) writeStdio(data []byte) {
+	i.stdio = append(i.stdio, data...)
+	for {
+		idx := bytes.IndexByte(i.stdio, '\n')
+		if idx < 0 {
+			return
+		}
+		i.appendLog(pbsubstreams.LogLevel_LOG_LEVEL_INFO, string(i.stdio[:idx]), nil)
+		i.stdio = i.stdio[idx+1:]
+	}
+}
+
+// wasiResult flushes pending output and interprets a `proc_exit`, exiting
+// with code 0 being a successful execution.
+func (i *Instance) wasiResult(err error) error {
+	if len(i.stdio) > 0 {
+		i.appendLog(pbsubstreams.LogLevel_LOG_LEVEL_INFO, string(i.stdio), nil)
+		i.stdio = nil
+	}
+	if i.exitCode == nil {
+		return err
+	}
+	code := *i.exitCode
+	i.exitCode = nil
+	if code == 0 {
+		return nil
+	}
+	return &ProcExitError{Code: code}
+}
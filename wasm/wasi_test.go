@@ -0,0 +1,84 @@
+package wasm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bytecodealliance/wasmtime-go"
+	pbsubstreams "github.com/streamingfast/substreams/pb/sf/substreams/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// echoes its first input as output, after printing a line and a half to
+// stdout and exiting with the code found in its second input
+const wasiModuleText = `
+(module
+  (import "env" "input_len" (func $input_len (param i32) (result i32)))
+  (import "env" "input_read" (func $input_read (param i32 i32)))
+  (import "env" "output" (func $output (param i32 i32)))
+  (import "wasi_snapshot_preview1" "fd_write" (func $fd_write (param i32 i32 i32 i32) (result i32)))
+  (import "wasi_snapshot_preview1" "proc_exit" (func $proc_exit (param i32)))
+  (memory (export "memory") 1)
+  (data (i32.const 16) "hello\nwor")
+  (func (export "map_test")
+    (i32.store (i32.const 0) (i32.const 16))
+    (i32.store (i32.const 4) (i32.const 9))
+    (drop (call $fd_write (i32.const 1) (i32.const 0) (i32.const 1) (i32.const 8)))
+    (call $input_read (i32.const 0) (i32.const 1024))
+    (call $output (i32.const 1024) (call $input_len (i32.const 0)))
+    (call $input_read (i32.const 1) (i32.const 64))
+    (call $proc_exit (i32.load8_u (i32.const 64))))
+)`
+
+func TestModule_WASI(t *testing.T) {
+	code, err := wasmtime.Wat2Wasm(wasiModuleText)
+	require.NoError(t, err)
+
+	module, err := NewRuntime(nil).NewModule(context.Background(), nil, BinaryTypeWASIV1, code, "map_test", "", "map_test")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		exitCode    byte
+		expectedErr string
+	}{
+		{"exit 0", 0, ""},
+		{"exit 3", 3, "module exited with code 3"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			input := NewMapInput("map")
+			input.SetValue([]byte("output"))
+			exitCode := NewMapInput("exit_code")
+			exitCode.SetValue([]byte{test.exitCode})
+
+			instance, err := module.NewInstance(&pbsubstreams.Clock{Number: 1}, []Argument{input, exitCode})
+			require.NoError(t, err)
+
+			err = instance.Execute()
+			if test.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, []byte("output"), instance.Output())
+			assert.Equal(t, []string{"hello", "wor"}, instance.Logs)
+		})
+	}
+}
+
+func TestModule_RustRequiresAllocator(t *testing.T) {
+	code, err := wasmtime.Wat2Wasm(`(module (memory (export "memory") 1) (func (export "map_test")))`)
+	require.NoError(t, err)
+
+	runtime := NewRuntime(nil)
+	_, err = runtime.NewModule(context.Background(), nil, BinaryTypeRustV1, code, "map_test", "", "map_test")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `does not export "alloc" and "dealloc"`)
+
+	_, err = runtime.NewModule(context.Background(), nil, BinaryTypeWASIV1, code, "map_test", "", "map_test")
+	require.NoError(t, err)
+}